@@ -1,18 +1,33 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
 	"log/slog"
+	"net"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/bajankristof/gerry/config"
+	"github.com/bajankristof/gerry/prompts"
+	"github.com/bajankristof/gerry/resources"
 	"github.com/bajankristof/gerry/tools"
 	"github.com/mark3labs/mcp-go/server"
 )
 
 func main() {
+	readOnly := flag.Bool("read-only", false, "register only tools that do not modify anything in Gerrit")
+	dryRun := flag.Bool("dry-run", false, "return the requests mutating tools would send instead of sending them")
+	transport := flag.String("transport", "stdio", "the transport to serve MCP over: stdio, http (streamable HTTP) or sse")
+	listen := flag.String("listen", "localhost:8080", "the address to listen on with the http and sse transports")
+	flag.Parse()
+
+	if *transport != "stdio" && *transport != "http" && *transport != "sse" {
+		log.Fatalf("Unknown transport: %s", *transport)
+	}
+
 	// Configure slog to write to stderr (MCP uses stdout)
 	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
 		Level: slog.LevelDebug,
@@ -23,19 +38,55 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
+	// Flags can only enable the safe modes, never disable them when set in the config
+	cfg.ReadOnly = cfg.ReadOnly || *readOnly
+	cfg.DryRun = cfg.DryRun || *dryRun
+
+	// A shared server acts with the credentials of each session, never its own
+	cfg.SessionCredentials = *transport != "stdio"
+
+	// Session credentials arrive as basic auth over plain HTTP
+	if cfg.SessionCredentials && !isLoopback(*listen) {
+		slog.Warn("Listening on a non-loopback address over plain HTTP: Gerrit credentials sent by clients can be read on the network. Listen on localhost behind a TLS-terminating proxy instead.", "address", *listen)
+	}
+
+	// The watcher receives resource subscriptions through the server hooks
+	watcher := resources.NewWatcher(cfg, resources.DefaultPollInterval)
+
 	s := server.NewMCPServer(
 		"gerry",
 		"1.0.0",
 		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(true, false),
+		server.WithPromptCapabilities(false),
+		server.WithHooks(watcher.Hooks()),
 	)
 	tools.Inject(s, cfg)
+	resources.Inject(s, cfg)
+	prompts.Inject(s, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go watcher.Run(ctx, s)
 
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
 
 	errs := make(chan error, 1)
 	go func() {
-		if err := server.ServeStdio(s); err != nil {
+		var err error
+		switch *transport {
+		case "http":
+			slog.Info("Serving MCP over streamable HTTP", "address", *listen)
+			err = server.NewStreamableHTTPServer(s).Start(*listen)
+		case "sse":
+			slog.Info("Serving MCP over SSE", "address", *listen)
+			err = server.NewSSEServer(s).Start(*listen)
+		default:
+			err = server.NewStdioServer(s).Listen(ctx, os.Stdin, os.Stdout)
+		}
+		if err != nil {
 			errs <- err
 		}
 	}()
@@ -47,3 +98,18 @@ func main() {
 		log.Fatalf("Server error: %v", err)
 	}
 }
+
+// isLoopback reports whether a listen address only accepts connections from the local machine
+func isLoopback(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+func TestIsLoopback(t *testing.T) {
+	tests := []struct {
+		address string
+		want    bool
+	}{
+		{"localhost:8080", true},
+		{"127.0.0.1:8080", true},
+		{"127.1.2.3:8080", true},
+		{"[::1]:8080", true},
+		{":8080", false},
+		{"0.0.0.0:8080", false},
+		{"[::]:8080", false},
+		{"192.168.1.10:8080", false},
+		{"gerry.example.com:8080", false},
+		{"localhost", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			if got := isLoopback(tt.address); got != tt.want {
+				t.Errorf("isLoopback(%q) = %v, want %v", tt.address, got, tt.want)
+			}
+		})
+	}
+}
@@ -5,22 +5,177 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 )
 
-var (
-	// ErrNoConfigFile is returned when the config file does not exist
-	ErrNoConfigFile = errors.New("configuration file not found")
+// DefaultRequestTimeout is the timeout applied to Gerrit requests when none is configured
+const DefaultRequestTimeout = 30 * time.Second
 
+var (
 	// ErrNoGerritCredentials is returned when Gerrit credentials are missing
-	ErrNoGerritCredentials = errors.New("no Gerrit credentials found. Please set gerritUsername and gerritPassword in ~/.config/gerry.json")
+	ErrNoGerritCredentials = errors.New("no Gerrit credentials found. Please set GERRIT_USERNAME and GERRIT_PASSWORD, configure a git credential helper, ~/.netrc or ~/.gitcookies, or set gerritUsername and gerritPassword in ~/.config/gerry.json")
+
+	// ErrNoSessionCredentials is returned when a request to a shared server carries no Gerrit credentials
+	ErrNoSessionCredentials = errors.New("no Gerrit credentials in the request. Please send your Gerrit username and HTTP password as basic auth in the Authorization header")
 )
 
 // Config represents the configuration for Gerry
 type Config struct {
-	GerritUsername string `json:"gerritUsername,omitempty"`
-	GerritPassword string `json:"gerritPassword,omitempty"`
+	GerritUsername string                `json:"gerritUsername,omitempty"`
+	GerritPassword string                `json:"gerritPassword,omitempty"`
+	RequestTimeout string                `json:"requestTimeout,omitempty"`
+	GerritHost     string                `json:"gerritHost,omitempty"`
+	Remote         string                `json:"remote,omitempty"`
+	Hosts          map[string]HostConfig `json:"hosts,omitempty"`
+	// ReadOnly registers only the tools that do not modify anything in Gerrit
+	ReadOnly bool `json:"readOnly,omitempty"`
+	// DryRun makes mutating tools return the request they would send instead of sending it
+	DryRun bool `json:"dryRun,omitempty"`
+	// CredentialSources is the order in which credential sources are tried (default: DefaultCredentialSources)
+	CredentialSources []string `json:"credentialSources,omitempty"`
+	// SessionCredentials takes the credentials from each HTTP session instead of the credential sources,
+	// so a shared server never acts with its own account
+	SessionCredentials bool `json:"-"`
+}
+
+// HostConfig represents the configuration of a single Gerrit host, keyed by the host of its git remotes.
+// Empty fields fall back to the top-level configuration.
+type HostConfig struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// BaseURL is the web base URL of the Gerrit instance (e.g. https://corp.example.com/gerrit),
+	// used when it cannot be derived from the remote, such as for SSH remotes
+	BaseURL        string `json:"baseUrl,omitempty"`
+	RequestTimeout string `json:"requestTimeout,omitempty"`
+}
+
+// Timeout returns the timeout applied to each Gerrit request to the host
+func (h HostConfig) Timeout() time.Duration {
+	return parseTimeout(h.RequestTimeout)
+}
+
+// ForHost returns the configuration for a Gerrit host, with missing fields filled from the top-level configuration
+func (c *Config) ForHost(host string) HostConfig {
+	hostCfg := c.hostConfig(host)
+
+	// Credentials are only inherited as a pair, so a host never mixes two accounts
+	if hostCfg.Username == "" && hostCfg.Password == "" {
+		hostCfg.Username = c.GerritUsername
+		hostCfg.Password = c.GerritPassword
+	}
+	if hostCfg.RequestTimeout == "" {
+		hostCfg.RequestTimeout = c.RequestTimeout
+	}
+
+	return hostCfg
+}
+
+// hostConfig returns the configuration of a Gerrit host without the top-level fallbacks.
+// Hostnames are case-insensitive, so hosts are looked up in lower case, see normalize.
+func (c *Config) hostConfig(host string) HostConfig {
+	return c.Hosts[strings.ToLower(host)]
+}
+
+// Connection represents the settings and credentials used to connect to a Gerrit host
+type Connection struct {
+	// BaseURL is the configured web base URL of the host (empty derives it from the host or git remote)
+	BaseURL     string
+	Credentials Credentials
+	Timeout     time.Duration
+	DryRun      bool
+}
+
+// Connection resolves the credentials and settings used to connect to a Gerrit host
+func (c *Config) Connection(req CredentialRequest) (Connection, error) {
+	if baseURL := c.ForHost(req.Host).BaseURL; baseURL != "" {
+		req.BaseURL = baseURL
+	}
+
+	creds, err := c.CredentialsFor(req)
+	if err != nil {
+		return Connection{}, err
+	}
+
+	return c.connection(req.Host, creds), nil
+}
+
+// SessionConnection returns a Connection function for a request with the given HTTP headers,
+// which uses the session credentials in the headers when SessionCredentials is set
+func (c *Config) SessionConnection(header http.Header) func(req CredentialRequest) (Connection, error) {
+	if !c.SessionCredentials {
+		return c.Connection
+	}
+
+	return func(req CredentialRequest) (Connection, error) {
+		creds, ok := CredentialsFromHeader(header)
+		if !ok {
+			return Connection{}, ErrNoSessionCredentials
+		}
+
+		return c.connection(req.Host, creds), nil
+	}
+}
+
+// connection combines credentials with the settings of a Gerrit host
+func (c *Config) connection(host string, creds Credentials) Connection {
+	hostCfg := c.ForHost(host)
+	return Connection{
+		BaseURL:     hostCfg.BaseURL,
+		Credentials: creds,
+		Timeout:     hostCfg.Timeout(),
+		DryRun:      c.DryRun,
+	}
+}
+
+// trusts reports whether the credentials that are not bound to a host may be sent to the requested host
+func (c *Config) trusts(req CredentialRequest) bool {
+	return req.FromRemote || c.IsKnownHost(req.Host)
+}
+
+// IsKnownHost reports whether host is the configured Gerrit host or has a host configuration
+func (c *Config) IsKnownHost(host string) bool {
+	for _, known := range c.KnownHosts() {
+		if strings.EqualFold(known, host) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// KnownHosts returns the Gerrit hosts used to pick the Gerrit remote of a repository
+func (c *Config) KnownHosts() []string {
+	var hosts []string
+	if c.GerritHost != "" {
+		hosts = append(hosts, c.GerritHost)
+	}
+	for host := range c.Hosts {
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+// Timeout returns the timeout applied to each Gerrit request
+func (c *Config) Timeout() time.Duration {
+	return parseTimeout(c.RequestTimeout)
+}
+
+// parseTimeout parses a request timeout, falling back to DefaultRequestTimeout
+func parseTimeout(value string) time.Duration {
+	if value == "" {
+		return DefaultRequestTimeout
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return DefaultRequestTimeout
+	}
+
+	return timeout
 }
 
 // getPath returns the path to the configuration file
@@ -32,7 +187,8 @@ func getPath() (string, error) {
 	return filepath.Join(home, ".config", "gerry.json"), nil
 }
 
-// Load loads the configuration from the config file
+// Load loads the configuration from the config file. A missing config file yields an empty
+// configuration, as credentials may come from other sources.
 func Load() (*Config, error) {
 	configPath, err := getPath()
 	if err != nil {
@@ -42,7 +198,7 @@ func Load() (*Config, error) {
 	content, err := os.ReadFile(configPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, ErrNoConfigFile
+			return &Config{}, nil
 		}
 		return nil, err
 	}
@@ -52,10 +208,66 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
 
-	// Validate that credentials are present
-	if cfg.GerritUsername == "" || cfg.GerritPassword == "" {
-		return nil, ErrNoGerritCredentials
+	if err := cfg.normalize(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
 	}
 
 	return &cfg, nil
 }
+
+// normalize lowercases the configured hostnames, so they match hosts regardless of case
+func (c *Config) normalize() error {
+	c.GerritHost = strings.ToLower(c.GerritHost)
+
+	if len(c.Hosts) == 0 {
+		return nil
+	}
+
+	hosts := make(map[string]HostConfig, len(c.Hosts))
+	for host, hostCfg := range c.Hosts {
+		lower := strings.ToLower(host)
+		if _, ok := hosts[lower]; ok {
+			return fmt.Errorf("host %s is configured more than once", lower)
+		}
+		hosts[lower] = hostCfg
+	}
+	c.Hosts = hosts
+
+	return nil
+}
+
+// validate checks that timeouts and credential sources are valid
+func (c *Config) validate() error {
+	if err := validateTimeout(c.RequestTimeout); err != nil {
+		return err
+	}
+
+	for host, hostCfg := range c.Hosts {
+		if err := validateTimeout(hostCfg.RequestTimeout); err != nil {
+			return fmt.Errorf("%w (host: %s)", err, host)
+		}
+	}
+
+	if _, err := c.Providers(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateTimeout checks that a configured request timeout can be parsed
+func validateTimeout(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	if _, err := time.ParseDuration(value); err != nil {
+		return fmt.Errorf("invalid requestTimeout: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	cfg := &Config{
+		GerritHost: "Review.Example.com",
+		Hosts: map[string]HostConfig{
+			"Corp.Example.COM": {Username: "jane", Password: "secret"},
+		},
+	}
+	if err := cfg.normalize(); err != nil {
+		t.Fatalf("normalize() error = %v", err)
+	}
+
+	if cfg.GerritHost != "review.example.com" {
+		t.Errorf("GerritHost = %q, want %q", cfg.GerritHost, "review.example.com")
+	}
+	if want := []string{"corp.example.com"}; !reflect.DeepEqual(keys(cfg.Hosts), want) {
+		t.Errorf("Hosts = %v, want %v", keys(cfg.Hosts), want)
+	}
+
+	duplicate := &Config{Hosts: map[string]HostConfig{"review.example.com": {}, "REVIEW.example.com": {}}}
+	if err := duplicate.normalize(); err == nil {
+		t.Error("normalize() with hosts differing only in case returned no error")
+	}
+}
+
+func TestForHost(t *testing.T) {
+	cfg := &Config{
+		GerritUsername: "top",
+		GerritPassword: "top-secret",
+		RequestTimeout: "10s",
+		Hosts: map[string]HostConfig{
+			"corp.example.com":  {Username: "jane", Password: "secret", BaseURL: "https://corp.example.com/gerrit"},
+			"other.example.com": {RequestTimeout: "1m"},
+		},
+	}
+
+	tests := []struct {
+		host string
+		want HostConfig
+	}{
+		{"corp.example.com", HostConfig{Username: "jane", Password: "secret", BaseURL: "https://corp.example.com/gerrit", RequestTimeout: "10s"}},
+		{"Corp.Example.com", HostConfig{Username: "jane", Password: "secret", BaseURL: "https://corp.example.com/gerrit", RequestTimeout: "10s"}},
+		{"other.example.com", HostConfig{Username: "top", Password: "top-secret", RequestTimeout: "1m"}},
+		{"unknown.example.com", HostConfig{Username: "top", Password: "top-secret", RequestTimeout: "10s"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			if got := cfg.ForHost(tt.host); got != tt.want {
+				t.Errorf("ForHost(%q) = %+v, want %+v", tt.host, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionConnection(t *testing.T) {
+	basic := func(username, password string) http.Header {
+		request, _ := http.NewRequest(http.MethodGet, "/", nil)
+		request.SetBasicAuth(username, password)
+		return request.Header
+	}
+
+	tests := []struct {
+		name     string
+		session  bool
+		header   http.Header
+		want     Credentials
+		wantErr  error
+		wantBase string
+	}{
+		{
+			name:     "own credentials",
+			header:   basic("mallory", "stolen"),
+			want:     Credentials{Username: "jane", Password: "secret", Source: SourceConfig},
+			wantBase: "https://review.example.com/gerrit",
+		},
+		{
+			name:     "session credentials",
+			session:  true,
+			header:   basic("john", "his-secret"),
+			want:     Credentials{Username: "john", Password: "his-secret", Source: SourceSession},
+			wantBase: "https://review.example.com/gerrit",
+		},
+		{
+			name:    "session without credentials",
+			session: true,
+			header:  http.Header{},
+			wantErr: ErrNoSessionCredentials,
+		},
+		{
+			name:    "session with a username only",
+			session: true,
+			header:  basic("john", ""),
+			wantErr: ErrNoSessionCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Hosts: map[string]HostConfig{
+					"review.example.com": {Username: "jane", Password: "secret", BaseURL: "https://review.example.com/gerrit"},
+				},
+				CredentialSources:  []string{SourceConfig},
+				SessionCredentials: tt.session,
+			}
+
+			conn, err := cfg.SessionConnection(tt.header)(CredentialRequest{Host: "review.example.com"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SessionConnection() error = %v, want %v", err, tt.wantErr)
+			}
+			if conn.Credentials.Username != tt.want.Username || conn.Credentials.Password != tt.want.Password || conn.Credentials.Source != tt.want.Source {
+				t.Errorf("SessionConnection() credentials = %+v, want %+v", conn.Credentials, tt.want)
+			}
+			if conn.BaseURL != tt.wantBase {
+				t.Errorf("SessionConnection() base URL = %q, want %q", conn.BaseURL, tt.wantBase)
+			}
+		})
+	}
+}
+
+// keys returns the keys of a host map
+func keys(hosts map[string]HostConfig) []string {
+	result := make([]string, 0, len(hosts))
+	for host := range hosts {
+		result = append(result, host)
+	}
+	return result
+}
@@ -0,0 +1,379 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Credential source names, as used in the credentialSources setting
+const (
+	SourceConfig        = "config"
+	SourceEnv           = "env"
+	SourceGitCredential = "git-credential"
+	SourceNetrc         = "netrc"
+	SourceGitCookies    = "gitcookies"
+	// SourceSession is reported for credentials sent with an HTTP session, see Config.SessionCredentials
+	SourceSession = "session"
+)
+
+// DefaultCredentialSources is the order in which credential sources are tried when none is configured
+var DefaultCredentialSources = []string{SourceConfig, SourceEnv, SourceGitCredential, SourceNetrc, SourceGitCookies}
+
+// Credentials represents the credentials used to authenticate with a Gerrit host,
+// either HTTP basic auth or a cookie as used by googlesource.com
+type Credentials struct {
+	Username string
+	Password string
+	Cookie   *http.Cookie
+	// Source is the name of the source the credentials were found in
+	Source string
+}
+
+// CredentialRequest describes the Gerrit host credentials are looked up for
+type CredentialRequest struct {
+	Host string
+	// BaseURL is the URL the host is reached at, used to tell git credential helpers the protocol
+	BaseURL string
+	// Directory is the git repository the host was found in, for repository-level credential helpers
+	Directory string
+	// FromRemote is true when the host was read from a git remote of the repository rather than
+	// supplied by a client, and so may receive the credentials that are not bound to a host
+	FromRemote bool
+}
+
+// protocol returns the protocol the host is reached with, defaulting to https
+func (r CredentialRequest) protocol() string {
+	if u, err := url.Parse(r.BaseURL); err == nil && u.Scheme == "http" {
+		return "http"
+	}
+	return "https"
+}
+
+// CredentialProvider looks up credentials for a Gerrit host
+type CredentialProvider interface {
+	// Name returns the name of the credential source
+	Name() string
+	// Credentials returns the credentials for the host, or ok == false if the source has none
+	Credentials(req CredentialRequest) (creds Credentials, ok bool, err error)
+}
+
+// Providers returns the credential providers to try, in order
+func (c *Config) Providers() ([]CredentialProvider, error) {
+	sources := c.CredentialSources
+	if len(sources) == 0 {
+		sources = DefaultCredentialSources
+	}
+
+	providers := make([]CredentialProvider, 0, len(sources))
+	for _, source := range sources {
+		switch source {
+		case SourceConfig:
+			providers = append(providers, configProvider{cfg: c})
+		case SourceEnv:
+			providers = append(providers, envProvider{cfg: c})
+		case SourceGitCredential:
+			providers = append(providers, gitCredentialProvider{})
+		case SourceNetrc:
+			providers = append(providers, netrcProvider{cfg: c})
+		case SourceGitCookies:
+			providers = append(providers, gitCookiesProvider{})
+		default:
+			return nil, fmt.Errorf("unknown credential source: %s", source)
+		}
+	}
+
+	return providers, nil
+}
+
+// CredentialsFor resolves the credentials for a Gerrit host by trying each credential source in order
+func (c *Config) CredentialsFor(req CredentialRequest) (Credentials, error) {
+	providers, err := c.Providers()
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	for _, provider := range providers {
+		creds, ok, err := provider.Credentials(req)
+		if err != nil {
+			return Credentials{}, fmt.Errorf("failed to read credentials from %s: %w", provider.Name(), err)
+		}
+
+		if ok {
+			creds.Source = provider.Name()
+			return creds, nil
+		}
+	}
+
+	return Credentials{}, fmt.Errorf("%w (host: %s)", ErrNoGerritCredentials, req.Host)
+}
+
+// CredentialsFromHeader returns the Gerrit credentials sent as basic auth in the Authorization header of an HTTP request
+func CredentialsFromHeader(header http.Header) (Credentials, bool) {
+	request := http.Request{Header: header}
+
+	username, password, ok := request.BasicAuth()
+	if !ok || username == "" || password == "" {
+		return Credentials{}, false
+	}
+
+	return Credentials{Username: username, Password: password, Source: SourceSession}, true
+}
+
+// configProvider reads credentials from gerry.json, per host first and then the top-level fields.
+// The top-level fields are only sent to known hosts and to the host of the repository's remote.
+type configProvider struct {
+	cfg *Config
+}
+
+func (p configProvider) Name() string {
+	return SourceConfig
+}
+
+func (p configProvider) Credentials(req CredentialRequest) (Credentials, bool, error) {
+	hostCfg := p.cfg.hostConfig(req.Host)
+	if p.cfg.trusts(req) {
+		hostCfg = p.cfg.ForHost(req.Host)
+	}
+
+	if hostCfg.Username == "" || hostCfg.Password == "" {
+		return Credentials{}, false, nil
+	}
+
+	return Credentials{Username: hostCfg.Username, Password: hostCfg.Password}, true, nil
+}
+
+// envProvider reads credentials from the GERRIT_USERNAME and GERRIT_PASSWORD environment variables.
+// They are only sent to known hosts and to the host of the repository's remote.
+type envProvider struct {
+	cfg *Config
+}
+
+func (p envProvider) Name() string {
+	return SourceEnv
+}
+
+func (p envProvider) Credentials(req CredentialRequest) (Credentials, bool, error) {
+	if !p.cfg.trusts(req) {
+		return Credentials{}, false, nil
+	}
+
+	username, password := os.Getenv("GERRIT_USERNAME"), os.Getenv("GERRIT_PASSWORD")
+	if username == "" || password == "" {
+		return Credentials{}, false, nil
+	}
+
+	return Credentials{Username: username, Password: password}, true, nil
+}
+
+// gitCredentialProvider asks the configured git credential helpers via git credential fill
+type gitCredentialProvider struct{}
+
+func (p gitCredentialProvider) Name() string {
+	return SourceGitCredential
+}
+
+func (p gitCredentialProvider) Credentials(req CredentialRequest) (Credentials, bool, error) {
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Dir = req.Directory
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("protocol=%s\nhost=%s\n\n", req.protocol(), req.Host))
+	// Never prompt: stdin and stdout belong to the MCP transport
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	output, err := cmd.Output()
+	if err != nil {
+		// git credential fill fails when no helper has credentials and prompting is disabled
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return Credentials{}, false, nil
+		}
+		return Credentials{}, false, err
+	}
+
+	var creds Credentials
+	for _, line := range strings.Split(string(output), "\n") {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case "username":
+			creds.Username = value
+		case "password":
+			creds.Password = value
+		}
+	}
+
+	if creds.Username == "" || creds.Password == "" {
+		return Credentials{}, false, nil
+	}
+
+	return creds, true, nil
+}
+
+// netrcProvider reads credentials from $NETRC or ~/.netrc. The default entry is not bound to
+// a host, so it is only used for the configured Gerrit hosts.
+type netrcProvider struct {
+	cfg *Config
+}
+
+func (p netrcProvider) Name() string {
+	return SourceNetrc
+}
+
+func (p netrcProvider) Credentials(req CredentialRequest) (Credentials, bool, error) {
+	path := os.Getenv("NETRC")
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return Credentials{}, false, nil
+		}
+		path = filepath.Join(home, ".netrc")
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Credentials{}, false, nil
+		}
+		return Credentials{}, false, err
+	}
+
+	creds, ok := parseNetrc(string(content), req.Host, p.cfg.IsKnownHost(req.Host))
+	return creds, ok, nil
+}
+
+// parseNetrc finds the login and password for host in netrc content, falling back to the default
+// entry if useDefault is set
+func parseNetrc(content, host string, useDefault bool) (Credentials, bool) {
+	var current, fallback *Credentials
+	var found *Credentials
+
+	tokens := strings.Fields(content)
+	for i := 0; i < len(tokens); i++ {
+		switch tokens[i] {
+		case "machine":
+			current = nil
+			if i+1 < len(tokens) {
+				i++
+				if strings.EqualFold(tokens[i], host) && found == nil {
+					found = &Credentials{}
+					current = found
+				}
+			}
+		case "default":
+			current = nil
+			if fallback == nil && useDefault {
+				fallback = &Credentials{}
+				current = fallback
+			}
+		case "login":
+			if i+1 < len(tokens) {
+				i++
+				if current != nil {
+					current.Username = tokens[i]
+				}
+			}
+		case "password":
+			if i+1 < len(tokens) {
+				i++
+				if current != nil {
+					current.Password = tokens[i]
+				}
+			}
+		case "account":
+			i++
+		case "macdef":
+			// Macro definitions run until the end of the file for our purposes
+			return pickNetrc(found, fallback)
+		}
+	}
+
+	return pickNetrc(found, fallback)
+}
+
+// pickNetrc returns the machine entry if complete, otherwise the default entry
+func pickNetrc(found, fallback *Credentials) (Credentials, bool) {
+	for _, creds := range []*Credentials{found, fallback} {
+		if creds != nil && creds.Username != "" && creds.Password != "" {
+			return *creds, true
+		}
+	}
+	return Credentials{}, false
+}
+
+// gitCookiesProvider reads an authentication cookie from git's http.cookiefile or ~/.gitcookies
+type gitCookiesProvider struct{}
+
+func (p gitCookiesProvider) Name() string {
+	return SourceGitCookies
+}
+
+func (p gitCookiesProvider) Credentials(req CredentialRequest) (Credentials, bool, error) {
+	// Read the setting in the repository, so a cookie file configured for it wins over the global one
+	path := ""
+	cmd := exec.Command("git", "config", "--path", "http.cookiefile")
+	cmd.Dir = req.Directory
+	if output, err := cmd.Output(); err == nil {
+		path = strings.TrimSpace(string(output))
+	}
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return Credentials{}, false, nil
+		}
+		path = filepath.Join(home, ".gitcookies")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Credentials{}, false, nil
+		}
+		return Credentials{}, false, err
+	}
+	defer file.Close()
+
+	// Netscape cookie file format: domain, include subdomains, path, secure, expiry, name, value
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimPrefix(scanner.Text(), "#HttpOnly_")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) != 7 {
+			continue
+		}
+
+		domain, subdomains := fields[0], fields[1] == "TRUE"
+		if !cookieDomainMatches(domain, subdomains, req.Host) {
+			continue
+		}
+
+		return Credentials{Cookie: &http.Cookie{Name: fields[5], Value: fields[6]}}, true, nil
+	}
+
+	return Credentials{}, false, scanner.Err()
+}
+
+// cookieDomainMatches reports whether a cookie set for domain applies to host
+func cookieDomainMatches(domain string, subdomains bool, host string) bool {
+	domain, host = strings.ToLower(domain), strings.ToLower(host)
+	if strings.HasPrefix(domain, ".") {
+		subdomains = true
+		domain = domain[1:]
+	}
+	if host == domain {
+		return true
+	}
+	return subdomains && strings.HasSuffix(host, "."+domain)
+}
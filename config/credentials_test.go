@@ -0,0 +1,198 @@
+package config
+
+import (
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestTrusts(t *testing.T) {
+	cfg := &Config{
+		GerritHost: "review.example.com",
+		Hosts:      map[string]HostConfig{"corp.example.com": {}},
+	}
+
+	tests := []struct {
+		name string
+		req  CredentialRequest
+		want bool
+	}{
+		{name: "gerrit host", req: CredentialRequest{Host: "review.example.com"}, want: true},
+		{name: "configured host", req: CredentialRequest{Host: "corp.example.com"}, want: true},
+		{name: "configured host in another case", req: CredentialRequest{Host: "Corp.Example.com"}, want: true},
+		{name: "unknown host", req: CredentialRequest{Host: "evil.example.com"}, want: false},
+		{name: "unknown host of the repository's remote", req: CredentialRequest{Host: "git.example.com", FromRemote: true}, want: true},
+		{name: "subdomain of a known host", req: CredentialRequest{Host: "x.review.example.com"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cfg.trusts(tt.req); got != tt.want {
+				t.Errorf("trusts(%+v) = %v, want %v", tt.req, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnboundCredentials(t *testing.T) {
+	t.Setenv("GERRIT_USERNAME", "env-user")
+	t.Setenv("GERRIT_PASSWORD", "env-password")
+
+	cfg := &Config{
+		GerritUsername: "top",
+		GerritPassword: "top-secret",
+		GerritHost:     "review.example.com",
+		Hosts: map[string]HostConfig{
+			"corp.example.com": {Username: "jane", Password: "secret"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		source  string
+		req     CredentialRequest
+		want    string
+		wantErr bool
+	}{
+		{name: "config for the gerrit host", source: SourceConfig, req: CredentialRequest{Host: "review.example.com"}, want: "top"},
+		{name: "config for a configured host", source: SourceConfig, req: CredentialRequest{Host: "corp.example.com"}, want: "jane"},
+		{name: "config for an unknown host", source: SourceConfig, req: CredentialRequest{Host: "evil.example.com"}, wantErr: true},
+		{name: "config for the remote's host", source: SourceConfig, req: CredentialRequest{Host: "git.example.com", FromRemote: true}, want: "top"},
+		{name: "env for the gerrit host", source: SourceEnv, req: CredentialRequest{Host: "review.example.com"}, want: "env-user"},
+		{name: "env for an unknown host", source: SourceEnv, req: CredentialRequest{Host: "evil.example.com"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg.CredentialSources = []string{tt.source}
+
+			creds, err := cfg.CredentialsFor(tt.req)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("CredentialsFor(%+v) = %+v, want an error", tt.req, creds)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CredentialsFor(%+v) error = %v", tt.req, err)
+			}
+			if creds.Username != tt.want || creds.Source != tt.source {
+				t.Errorf("CredentialsFor(%+v) = %s from %s, want %s from %s", tt.req, creds.Username, creds.Source, tt.want, tt.source)
+			}
+		})
+	}
+}
+
+func TestParseNetrc(t *testing.T) {
+	const netrc = `machine review.example.com
+  login jane
+  password secret
+
+machine partial.example.com login bob
+
+default login anyone password anything
+
+machine late.example.com login late password late-secret
+`
+
+	tests := []struct {
+		name       string
+		host       string
+		useDefault bool
+		want       Credentials
+		wantOK     bool
+	}{
+		{name: "machine entry", host: "review.example.com", want: Credentials{Username: "jane", Password: "secret"}, wantOK: true},
+		{name: "machine entry in another case", host: "Review.Example.com", want: Credentials{Username: "jane", Password: "secret"}, wantOK: true},
+		{name: "machine after the default entry", host: "late.example.com", want: Credentials{Username: "late", Password: "late-secret"}, wantOK: true},
+		{name: "incomplete entry falls back to the default", host: "partial.example.com", useDefault: true, want: Credentials{Username: "anyone", Password: "anything"}, wantOK: true},
+		{name: "default entry", host: "other.example.com", useDefault: true, want: Credentials{Username: "anyone", Password: "anything"}, wantOK: true},
+		{name: "default entry not allowed", host: "other.example.com", useDefault: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseNetrc(netrc, tt.host, tt.useDefault)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("parseNetrc(%q, %v) = %+v, %v, want %+v, %v", tt.host, tt.useDefault, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCookieDomainMatches(t *testing.T) {
+	tests := []struct {
+		domain     string
+		subdomains bool
+		host       string
+		want       bool
+	}{
+		{"review.example.com", false, "review.example.com", true},
+		{"review.example.com", false, "Review.Example.com", true},
+		{"review.example.com", false, "x.review.example.com", false},
+		{"example.com", true, "review.example.com", true},
+		{".example.com", false, "review.example.com", true},
+		{".example.com", false, "example.com", true},
+		{".example.com", false, "badexample.com", false},
+		{"example.com", true, "example.com.evil.org", false},
+	}
+
+	for _, tt := range tests {
+		if got := cookieDomainMatches(tt.domain, tt.subdomains, tt.host); got != tt.want {
+			t.Errorf("cookieDomainMatches(%q, %v, %q) = %v, want %v", tt.domain, tt.subdomains, tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestGitCookiesRepositoryCookieFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	writeCookies := func(path, value string) {
+		t.Helper()
+		content := "review.example.com\tFALSE\t/\tTRUE\t2147483647\to\t" + value + "\n"
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeCookies(filepath.Join(home, ".gitcookies"), "global")
+
+	repo := t.TempDir()
+	repoCookies := filepath.Join(repo, "cookies")
+	writeCookies(repoCookies, "repository")
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"config", "http.cookiefile", repoCookies},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		directory string
+		want      string
+	}{
+		{name: "repository cookie file", directory: repo, want: "repository"},
+		{name: "default cookie file", directory: home, want: "global"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			creds, ok, err := gitCookiesProvider{}.Credentials(CredentialRequest{Host: "review.example.com", Directory: tt.directory})
+			if err != nil || !ok {
+				t.Fatalf("Credentials() = %v, %v", ok, err)
+			}
+			if want := (&http.Cookie{Name: "o", Value: tt.want}); creds.Cookie.String() != want.String() {
+				t.Errorf("Credentials() cookie = %s, want %s", creds.Cookie, want)
+			}
+		})
+	}
+}
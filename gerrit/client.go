@@ -2,9 +2,14 @@
 package gerrit
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/bajankristof/gerry/git"
 	"resty.dev/v3"
@@ -13,6 +18,21 @@ import (
 var (
 	// ErrNoGerritHost is returned when the Gerrit host cannot be determined
 	ErrNoGerritHost = errors.New("could not determine Gerrit host. Please provide a directory with a git remote configured")
+
+	// ErrPatchSetNotFound is returned when a patch set does not exist on a change
+	ErrPatchSetNotFound = errors.New("patch set not found")
+
+	// ErrCommentNotFound is returned when a comment does not exist on a change
+	ErrCommentNotFound = errors.New("comment not found")
+
+	// ErrSideMismatch is returned when a reply is drafted on another side of the diff than its parent comment
+	ErrSideMismatch = errors.New("a reply must be on the same side as the comment it replies to")
+
+	// ErrLabelNotPermitted is returned when voting on a label the user may not vote on
+	ErrLabelNotPermitted = errors.New("label is not permitted on this change")
+
+	// ErrInvalidLabelScore is returned when a vote is outside the permitted range of a label
+	ErrInvalidLabelScore = errors.New("invalid label score")
 )
 
 // Author represents a comment author
@@ -21,6 +41,25 @@ type Author struct {
 	Email string `json:"email"`
 }
 
+// DisplayName returns the name and email of the author, or whichever of them is set
+func (a Author) DisplayName() string {
+	return displayName(a.Name, a.Email, "")
+}
+
+// displayName formats a user for display, preferring the name and falling back to the username
+func displayName(name, email, username string) string {
+	switch {
+	case name != "" && email != "":
+		return fmt.Sprintf("%s <%s>", name, email)
+	case name != "":
+		return name
+	case email != "":
+		return email
+	default:
+		return username
+	}
+}
+
 // Range represents a comment range
 type Range struct {
 	StartLine      int `json:"start_line"`
@@ -37,39 +76,87 @@ type Comment struct {
 	Path       string `json:"path,omitempty"`
 	Line       int    `json:"line,omitempty"`
 	Range      *Range `json:"range,omitempty"`
+	Side       string `json:"side,omitempty"`
 	Unresolved bool   `json:"unresolved"`
 	PatchSet   int    `json:"patch_set"`
 	InReplyTo  string `json:"in_reply_to,omitempty"`
+	Updated    string `json:"updated,omitempty"`
 }
 
 // Change represents a Gerrit change
 type Change struct {
-	ID              string `json:"id"`
-	ChangeID        string `json:"change_id"`
-	Project         string `json:"project"`
-	Branch          string `json:"branch"`
-	Subject         string `json:"subject"`
-	Status          string `json:"status"`
-	CurrentRevision string `json:"current_revision"`
+	ID              string   `json:"id"`
+	Number          int      `json:"_number"`
+	ChangeID        string   `json:"change_id"`
+	Project         string   `json:"project"`
+	Branch          string   `json:"branch"`
+	Topic           string   `json:"topic,omitempty"`
+	Subject         string   `json:"subject"`
+	Status          string   `json:"status"`
+	Owner           *Account `json:"owner,omitempty"`
+	Updated         string   `json:"updated,omitempty"`
+	CurrentRevision string   `json:"current_revision"`
 	Revisions       map[string]struct {
-		Kind string `json:"kind"`
-		Ref  string `json:"ref"`
-	} `json:"revisions"`
+		Number int    `json:"_number"`
+		Kind   string `json:"kind"`
+		Ref    string `json:"ref"`
+	} `json:"revisions,omitempty"`
+	PermittedLabels map[string][]string `json:"permitted_labels,omitempty"`
+	MoreChanges     bool                `json:"_more_changes,omitempty"`
 }
 
 // Client provides methods to interact with Gerrit
 type Client struct {
 	host     string
+	baseURL  string
 	username string
 	password string
 	client   *resty.Client
 }
 
-// NewClient creates a new Gerrit client
+// HostOptions represents the settings used to connect to a Gerrit host
+type HostOptions struct {
+	// BaseURL is the web base URL of the Gerrit instance (empty derives it from the git remote)
+	BaseURL  string
+	Username string
+	Password string
+	// Cookie authenticates requests instead of basic auth when set, as with .gitcookies
+	Cookie  *http.Cookie
+	Timeout time.Duration
+	// DryRun skips mutating requests, see EnableDryRun
+	DryRun bool
+}
+
+// GitOptions controls how NewClientFromGit discovers Gerrit from a git repository
+type GitOptions struct {
+	// Remote is the name of the remote to use (empty picks one automatically)
+	Remote string
+	// Hosts are the known Gerrit hosts, used to pick the Gerrit remote
+	Hosts []string
+	// HostOptions returns the settings for the host of the remote that was picked
+	HostOptions func(remote git.Remote) (HostOptions, error)
+}
+
+// NewClient creates a new Gerrit client for a host served over HTTPS
 func NewClient(host, username, password string) *Client {
+	return NewClientWithBaseURL(fmt.Sprintf("https://%s", host), username, password)
+}
+
+// NewClientWithBaseURL creates a new Gerrit client for the Gerrit instance at baseURL
+// (e.g. https://corp.example.com/gerrit or http://localhost:8080)
+func NewClientWithBaseURL(baseURL, username, password string) *Client {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	host := baseURL
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		host = u.Hostname()
+	}
+
 	client := resty.New()
-	client.SetBasicAuth(username, password)
-	client.SetBaseURL(fmt.Sprintf("https://%s/a", host))
+	if username != "" || password != "" {
+		client.SetBasicAuth(username, password)
+	}
+	client.SetBaseURL(baseURL + "/a")
 	client.SetHeader("Content-Type", "application/json")
 	client.SetDoNotParseResponse(true)
 	client.AddResponseMiddleware(autoErrorMiddleware)
@@ -77,30 +164,64 @@ func NewClient(host, username, password string) *Client {
 
 	return &Client{
 		host:     host,
+		baseURL:  baseURL,
 		username: username,
 		password: password,
 		client:   client,
 	}
 }
 
-// NewClientFromGit creates a Gerrit client by extracting the host from a git repository
-func NewClientFromGit(directory, username, password string) (*Client, error) {
-	var host string
-	var err error
+// NewClientFromGit creates a Gerrit client by extracting the host from a git repository.
+// The remote is picked by name if given, otherwise the one matching a known Gerrit host or pushing to refs/for/* is used.
+func NewClientFromGit(directory string, opts GitOptions) (*Client, error) {
 	if directory == "" {
 		directory = "."
 	}
 
-	host, err = git.GetHostFromRemote(directory)
+	remote, err := git.GetRemote(directory, opts.Remote, opts.Hosts)
 	if err != nil {
 		return nil, err
 	}
 
-	if host == "" {
+	if remote.Host == "" {
 		return nil, ErrNoGerritHost
 	}
 
-	return NewClient(host, username, password), nil
+	var hostOpts HostOptions
+	if opts.HostOptions != nil {
+		hostOpts, err = opts.HostOptions(remote)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if hostOpts.BaseURL == "" {
+		hostOpts.BaseURL = remote.WebURL()
+	}
+
+	return NewClientForHost(remote.Host, hostOpts), nil
+}
+
+// NewClientForHost creates a Gerrit client for a host with the given settings,
+// served over HTTPS unless a base URL is given
+func NewClientForHost(host string, opts HostOptions) *Client {
+	baseURL := opts.BaseURL
+	if baseURL == "" {
+		baseURL = fmt.Sprintf("https://%s", host)
+	}
+
+	client := NewClientWithBaseURL(baseURL, opts.Username, opts.Password)
+	if opts.Cookie != nil {
+		client.SetCookie(opts.Cookie)
+	}
+	if opts.Timeout > 0 {
+		client.SetTimeout(opts.Timeout)
+	}
+	if opts.DryRun {
+		client.EnableDryRun()
+	}
+
+	return client
 }
 
 // Host returns the Gerrit host
@@ -108,11 +229,32 @@ func (c *Client) Host() string {
 	return c.host
 }
 
+// BaseURL returns the base URL of the Gerrit web UI
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// SetCookie authenticates requests with a cookie, e.g. one read from .gitcookies
+func (c *Client) SetCookie(cookie *http.Cookie) {
+	c.client.SetCookie(cookie)
+}
+
+// EnableDryRun makes the client skip mutating requests, returning a DryRunError describing each one instead
+func (c *Client) EnableDryRun() {
+	// Run after resty has prepared the request, so the exact URL with its query is reported
+	c.client.SetRequestMiddlewares(resty.PrepareRequestMiddleware, dryRunMiddleware)
+}
+
+// SetTimeout sets the timeout applied to each request (0 disables the timeout)
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.client.SetTimeout(timeout)
+}
+
 // GetChange gets change information by Change-Id
-func (c *Client) GetChange(changeID string) (Change, error) {
-	path := fmt.Sprintf("/changes/%s?o=ALL_REVISIONS", url.PathEscape(changeID))
+func (c *Client) GetChange(ctx context.Context, changeID string) (Change, error) {
+	path := fmt.Sprintf("/changes/%s?o=ALL_REVISIONS&o=DETAILED_LABELS", url.PathEscape(changeID))
 
-	resp, err := c.client.R().SetResult(Change{}).Get(path)
+	resp, err := c.client.R().SetContext(ctx).SetResult(Change{}).Get(path)
 	if err != nil {
 		return Change{}, err
 	}
@@ -120,11 +262,25 @@ func (c *Client) GetChange(changeID string) (Change, error) {
 	return *resp.Result().(*Change), nil
 }
 
+// Revision returns the commit SHA and fetch ref of a patch set of the change (0 returns the current revision)
+func (c Change) Revision(patchSet int) (commit, ref string, err error) {
+	for sha, revision := range c.Revisions {
+		if (patchSet == 0 && sha == c.CurrentRevision) || (patchSet != 0 && revision.Number == patchSet) {
+			return sha, revision.Ref, nil
+		}
+	}
+
+	if patchSet == 0 {
+		return "", "", fmt.Errorf("%w: current revision of change %d", ErrPatchSetNotFound, c.Number)
+	}
+	return "", "", fmt.Errorf("%w: patch set %d of change %d", ErrPatchSetNotFound, patchSet, c.Number)
+}
+
 // GetComments gets all comments for a change
-func (c *Client) GetComments(changeID string) ([]Comment, error) {
+func (c *Client) GetComments(ctx context.Context, changeID string) ([]Comment, error) {
 	path := fmt.Sprintf("/changes/%s/comments", url.PathEscape(changeID))
 
-	resp, err := c.client.R().SetResult(map[string][]Comment{}).Get(path)
+	resp, err := c.client.R().SetContext(ctx).SetResult(map[string][]Comment{}).Get(path)
 	if err != nil {
 		return nil, err
 	}
@@ -142,18 +298,17 @@ func (c *Client) GetComments(changeID string) ([]Comment, error) {
 	return result, nil
 }
 
-// GetUnresolvedComments gets all unresolved comments for a change
-func (c *Client) GetUnresolvedComments(changeID string) ([]Comment, error) {
-	comments, err := c.GetComments(changeID)
+// GetUnresolvedComments gets all comments in unresolved threads of a change.
+// A thread's state is decided by its last comment, see Thread.
+func (c *Client) GetUnresolvedComments(ctx context.Context, changeID string) ([]Comment, error) {
+	threads, err := c.GetUnresolvedThreads(ctx, changeID)
 	if err != nil {
 		return nil, err
 	}
 
 	var result []Comment
-	for _, comment := range comments {
-		if comment.Unresolved {
-			result = append(result, comment)
-		}
+	for _, thread := range threads {
+		result = append(result, thread.Comments...)
 	}
 
 	return result, nil
@@ -164,28 +319,86 @@ type DraftCommentInput struct {
 	Message    string `json:"message"`
 	Path       string `json:"path"`
 	Line       int    `json:"line,omitempty"`
+	Range      *Range `json:"range,omitempty"`
+	Side       string `json:"side,omitempty"`
 	InReplyTo  string `json:"in_reply_to,omitempty"`
 	Unresolved bool   `json:"unresolved,omitempty"`
 }
 
-// DraftComment creates a draft comment or reply
-func (c *Client) DraftComment(changeID string, input DraftCommentInput) error {
-	path := fmt.Sprintf("/changes/%s/revisions/current/drafts", url.PathEscape(changeID))
+// DraftComment creates a draft comment or reply on a revision of a change. If revision is empty,
+// replies are placed on the patch set of the comment they reply to and new comments on the current revision.
+func (c *Client) DraftComment(ctx context.Context, changeID, revision string, input DraftCommentInput) error {
+	if revision == "" && input.InReplyTo != "" {
+		parent, err := c.findComment(ctx, changeID, input.InReplyTo)
+		if err != nil {
+			return err
+		}
+
+		revision = strconv.Itoa(parent.PatchSet)
+
+		// Keep the reply anchored where the parent comment is unless told otherwise
+		if input.Line == 0 && input.Range == nil {
+			input.Line = parent.Line
+			input.Range = parent.Range
+		}
+		if input.Side == "" {
+			input.Side = parent.Side
+		} else if sideOf(input.Side) != sideOf(parent.Side) {
+			return fmt.Errorf("%w: comment %s is on the %s side, the reply on the %s side", ErrSideMismatch, parent.ID, sideOf(parent.Side), sideOf(input.Side))
+		}
+	}
+
+	if revision == "" {
+		revision = "current"
+	}
+
+	// Gerrit requires the line of a range comment to be its end line
+	if input.Range != nil {
+		input.Line = input.Range.EndLine
+	}
+
+	path := fmt.Sprintf("/changes/%s/revisions/%s/drafts", url.PathEscape(changeID), url.PathEscape(revision))
 
 	_, err := c.client.R().
+		SetContext(ctx).
 		SetBody(input).
 		Put(path)
 
 	return err
 }
 
+// sideOf returns the side of the diff a comment is on, as Gerrit leaves out the default REVISION side
+func sideOf(side string) string {
+	if side == "" {
+		return "REVISION"
+	}
+	return side
+}
+
+// findComment looks up a published comment by ID
+func (c *Client) findComment(ctx context.Context, changeID, commentID string) (Comment, error) {
+	comments, err := c.GetComments(ctx, changeID)
+	if err != nil {
+		return Comment{}, err
+	}
+
+	for _, comment := range comments {
+		if comment.ID == commentID {
+			return comment, nil
+		}
+	}
+
+	return Comment{}, fmt.Errorf("%w: %s", ErrCommentNotFound, commentID)
+}
+
 // PublishReviewInput represents a review to be published
 type PublishReviewInput struct {
-	Message string `json:"message,omitempty"`
+	Message string         `json:"message,omitempty"`
+	Labels  map[string]int `json:"labels,omitempty"`
 }
 
-// PublishReview publishes all draft comments for a change
-func (c *Client) PublishReview(changeID string, input PublishReviewInput) error {
+// PublishReview publishes all draft comments for a change and casts the given label votes
+func (c *Client) PublishReview(ctx context.Context, changeID string, input PublishReviewInput) error {
 	path := fmt.Sprintf("/changes/%s/revisions/current/review", url.PathEscape(changeID))
 
 	body := map[string]any{"drafts": "PUBLISH_ALL_REVISIONS"}
@@ -195,9 +408,56 @@ func (c *Client) PublishReview(changeID string, input PublishReviewInput) error
 		}
 	}
 
+	if len(input.Labels) > 0 {
+		change, err := c.GetChange(ctx, changeID)
+		if err != nil {
+			return err
+		}
+
+		if err := validateLabels(input.Labels, change.PermittedLabels); err != nil {
+			return err
+		}
+
+		body["labels"] = input.Labels
+	}
+
 	_, err := c.client.R().
+		SetContext(ctx).
 		SetBody(body).
 		Post(path)
 
 	return err
 }
+
+// validateLabels checks that every vote is within the scores the caller is permitted to cast
+func validateLabels(labels map[string]int, permitted map[string][]string) error {
+	for label, score := range labels {
+		values, ok := permitted[label]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrLabelNotPermitted, label)
+		}
+
+		allowed := false
+		for _, value := range values {
+			if v, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && v == score {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			return fmt.Errorf("%w: %+d for %s (allowed values: %s)", ErrInvalidLabelScore, score, label, strings.Join(trimAll(values), ", "))
+		}
+	}
+
+	return nil
+}
+
+// trimAll trims surrounding whitespace from every string in the slice
+func trimAll(values []string) []string {
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = strings.TrimSpace(value)
+	}
+	return result
+}
@@ -0,0 +1,103 @@
+package gerrit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDraftCommentReplySide(t *testing.T) {
+	tests := []struct {
+		name       string
+		parentSide string
+		side       string
+		wantSide   string
+		wantErr    error
+	}{
+		{name: "inherits the parent side", parentSide: "PARENT", wantSide: "PARENT"},
+		{name: "inherits the default side", wantSide: ""},
+		{name: "explicit side matching the parent", parentSide: "PARENT", side: "PARENT", wantSide: "PARENT"},
+		{name: "explicit default side matching the parent", side: "REVISION", wantSide: "REVISION"},
+		{name: "explicit side conflicting with the parent", parentSide: "PARENT", side: "REVISION", wantErr: ErrSideMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var put *DraftCommentInput
+			var putPath string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					parent := map[string]any{"id": "c1", "patch_set": 2, "line": 7, "side": tt.parentSide}
+					json.NewEncoder(w).Encode(map[string][]map[string]any{"a.go": {parent}})
+				case http.MethodPut:
+					body, _ := io.ReadAll(r.Body)
+					put = &DraftCommentInput{}
+					json.Unmarshal(body, put)
+					putPath = r.URL.Path
+					w.Write([]byte("{}"))
+				}
+			}))
+			defer server.Close()
+
+			client := NewClientWithBaseURL(server.URL, "", "")
+			err := client.DraftComment(context.Background(), "123", "", DraftCommentInput{
+				Message:   "Done",
+				Path:      "a.go",
+				Side:      tt.side,
+				InReplyTo: "c1",
+			})
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("DraftComment() error = %v, want %v", err, tt.wantErr)
+				}
+				if put != nil {
+					t.Error("DraftComment() sent the draft despite the error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DraftComment() error = %v", err)
+			}
+
+			if put == nil {
+				t.Fatal("DraftComment() did not send the draft")
+			}
+			if put.Side != tt.wantSide || put.Line != 7 || putPath != "/a/changes/123/revisions/2/drafts" {
+				t.Errorf("DraftComment() sent side %q line %d to %s, want side %q line 7 to patch set 2", put.Side, put.Line, putPath, tt.wantSide)
+			}
+		})
+	}
+}
+
+func TestValidateLabels(t *testing.T) {
+	permitted := map[string][]string{
+		"Code-Review": {"-2", "-1", " 0", "+1", "+2"},
+		"Verified":    {"-1", " 0", "+1"},
+	}
+
+	tests := []struct {
+		name    string
+		labels  map[string]int
+		wantErr error
+	}{
+		{name: "no labels"},
+		{name: "permitted scores", labels: map[string]int{"Code-Review": 2, "Verified": -1}},
+		{name: "zero score", labels: map[string]int{"Verified": 0}},
+		{name: "score out of range", labels: map[string]int{"Verified": 2}, wantErr: ErrInvalidLabelScore},
+		{name: "label not permitted", labels: map[string]int{"Library-Compliance": 1}, wantErr: ErrLabelNotPermitted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateLabels(tt.labels, permitted); !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateLabels() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DiffFileMeta represents the metadata of one side of a diff
+type DiffFileMeta struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Lines       int    `json:"lines"`
+}
+
+// DiffContent represents a block of lines in a diff
+type DiffContent struct {
+	A      []string `json:"a,omitempty"`
+	B      []string `json:"b,omitempty"`
+	AB     []string `json:"ab,omitempty"`
+	Skip   int      `json:"skip,omitempty"`
+	Common bool     `json:"common,omitempty"`
+}
+
+// DiffInfo represents the diff of a file in a revision
+type DiffInfo struct {
+	MetaA      *DiffFileMeta `json:"meta_a,omitempty"`
+	MetaB      *DiffFileMeta `json:"meta_b,omitempty"`
+	ChangeType string        `json:"change_type"`
+	DiffHeader []string      `json:"diff_header,omitempty"`
+	Content    []DiffContent `json:"content"`
+	Binary     bool          `json:"binary,omitempty"`
+}
+
+// DiffOptions represents the options for fetching a diff
+type DiffOptions struct {
+	// Base is the patch set number to diff against (0 diffs against the parent)
+	Base int
+	// Context is the number of context lines around each change (0 returns the whole file)
+	Context int
+	// Whitespace is one of IGNORE_NONE, IGNORE_TRAILING, IGNORE_LEADING_AND_TRAILING or IGNORE_ALL
+	Whitespace string
+}
+
+// GetDiff gets the diff of a file in a revision of a change
+func (c *Client) GetDiff(ctx context.Context, changeID, revision, path string, opts DiffOptions) (DiffInfo, error) {
+	if revision == "" {
+		revision = "current"
+	}
+
+	endpoint := fmt.Sprintf("/changes/%s/revisions/%s/files/%s/diff",
+		url.PathEscape(changeID), url.PathEscape(revision), url.PathEscape(path))
+
+	req := c.client.R().SetContext(ctx).SetResult(DiffInfo{})
+	if opts.Base > 0 {
+		req.SetQueryParam("base", strconv.Itoa(opts.Base))
+	}
+	if opts.Context > 0 {
+		req.SetQueryParam("context", strconv.Itoa(opts.Context))
+	} else {
+		req.SetQueryParam("context", "ALL")
+	}
+	if opts.Whitespace != "" {
+		req.SetQueryParam("whitespace", opts.Whitespace)
+	}
+
+	resp, err := req.Get(endpoint)
+	if err != nil {
+		return DiffInfo{}, err
+	}
+
+	return *resp.Result().(*DiffInfo), nil
+}
+
+// diffHunk represents a contiguous block of lines in a unified diff
+type diffHunk struct {
+	startA, startB int
+	countA, countB int
+	lines          []string
+}
+
+// Unified renders the diff as a unified diff
+func (d DiffInfo) Unified() string {
+	var sb strings.Builder
+
+	nameA, nameB := "/dev/null", "/dev/null"
+	if d.MetaA != nil {
+		nameA = "a/" + d.MetaA.Name
+	}
+	if d.MetaB != nil {
+		nameB = "b/" + d.MetaB.Name
+	}
+
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", nameA, nameB)
+
+	if d.Binary {
+		sb.WriteString("Binary files differ\n")
+		return sb.String()
+	}
+
+	var hunks []*diffHunk
+	var current *diffHunk
+	lineA, lineB := 1, 1
+
+	add := func(prefix string, lines []string, inA, inB bool) {
+		if current == nil {
+			current = &diffHunk{startA: lineA, startB: lineB}
+			hunks = append(hunks, current)
+		}
+		for _, line := range lines {
+			current.lines = append(current.lines, prefix+line)
+			if inA {
+				current.countA++
+				lineA++
+			}
+			if inB {
+				current.countB++
+				lineB++
+			}
+		}
+	}
+
+	for _, content := range d.Content {
+		if content.Skip > 0 {
+			current = nil
+			lineA += content.Skip
+			lineB += content.Skip
+			continue
+		}
+		add(" ", content.AB, true, true)
+		add("-", content.A, true, false)
+		add("+", content.B, false, true)
+	}
+
+	for _, hunk := range hunks {
+		if len(hunk.lines) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(hunk.startA, hunk.countA), hunkRange(hunk.startB, hunk.countB))
+		for _, line := range hunk.lines {
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// hunkRange formats the range of a hunk side the way diff(1) does
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
@@ -0,0 +1,153 @@
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestGetDiffQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		opts DiffOptions
+		want url.Values
+	}{
+		{
+			name: "context lines",
+			opts: DiffOptions{Context: 3},
+			want: url.Values{"context": {"3"}},
+		},
+		{
+			name: "whole file",
+			opts: DiffOptions{},
+			want: url.Values{"context": {"ALL"}},
+		},
+		{
+			name: "base and whitespace",
+			opts: DiffOptions{Base: 2, Context: 5, Whitespace: "IGNORE_ALL"},
+			want: url.Values{"base": {"2"}, "context": {"5"}, "whitespace": {"IGNORE_ALL"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *url.URL
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.URL
+				w.Write([]byte(")]}'\n{\"change_type\":\"MODIFIED\",\"content\":[]}"))
+			}))
+			defer server.Close()
+
+			client := NewClientWithBaseURL(server.URL, "", "")
+			if _, err := client.GetDiff(context.Background(), "123", "", "src/main.go", tt.opts); err != nil {
+				t.Fatalf("GetDiff() error = %v", err)
+			}
+
+			if want := "/a/changes/123/revisions/current/files/src%2Fmain.go/diff"; got.EscapedPath() != want {
+				t.Errorf("GetDiff() path = %s, want %s", got.EscapedPath(), want)
+			}
+			if query := got.Query(); query.Encode() != tt.want.Encode() {
+				t.Errorf("GetDiff() query = %s, want %s", query.Encode(), tt.want.Encode())
+			}
+		})
+	}
+}
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name string
+		diff DiffInfo
+		want string
+	}{
+		{
+			name: "modified",
+			diff: DiffInfo{
+				MetaA: &DiffFileMeta{Name: "main.go"},
+				MetaB: &DiffFileMeta{Name: "main.go"},
+				Content: []DiffContent{
+					{AB: []string{"package main"}},
+					{A: []string{"var x = 1"}, B: []string{"var x = 2", "var y = 3"}},
+					{AB: []string{"func main() {}"}},
+				},
+			},
+			want: `--- a/main.go
++++ b/main.go
+@@ -1,3 +1,4 @@
+ package main
+-var x = 1
++var x = 2
++var y = 3
+ func main() {}
+`,
+		},
+		{
+			name: "skipped lines split hunks",
+			diff: DiffInfo{
+				MetaA: &DiffFileMeta{Name: "main.go"},
+				MetaB: &DiffFileMeta{Name: "main.go"},
+				Content: []DiffContent{
+					{A: []string{"// old"}},
+					{AB: []string{"a"}},
+					{Skip: 10},
+					{AB: []string{"b"}},
+					{B: []string{"// new"}},
+				},
+			},
+			want: `--- a/main.go
++++ b/main.go
+@@ -1,2 +1,1 @@
+-// old
+ a
+@@ -13,1 +12,2 @@
+ b
++// new
+`,
+		},
+		{
+			name: "added file",
+			diff: DiffInfo{
+				MetaB:   &DiffFileMeta{Name: "new.go"},
+				Content: []DiffContent{{B: []string{"package main"}}},
+			},
+			want: `--- /dev/null
++++ b/new.go
+@@ -0,0 +1,1 @@
++package main
+`,
+		},
+		{
+			name: "deleted file",
+			diff: DiffInfo{
+				MetaA:   &DiffFileMeta{Name: "old.go"},
+				Content: []DiffContent{{A: []string{"package main"}}},
+			},
+			want: `--- a/old.go
++++ /dev/null
+@@ -1,1 +0,0 @@
+-package main
+`,
+		},
+		{
+			name: "binary",
+			diff: DiffInfo{
+				MetaA:  &DiffFileMeta{Name: "logo.png"},
+				MetaB:  &DiffFileMeta{Name: "logo.png"},
+				Binary: true,
+			},
+			want: `--- a/logo.png
++++ b/logo.png
+Binary files differ
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.diff.Unified(); got != tt.want {
+				t.Errorf("Unified() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package gerrit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// ErrDraftNotFound is returned when a draft comment does not exist on a change
+var ErrDraftNotFound = errors.New("draft comment not found")
+
+// UpdateDraftInput represents changes to a draft comment; nil fields are left unchanged
+type UpdateDraftInput struct {
+	Message    *string
+	Unresolved *bool
+}
+
+// ListDrafts lists the current user's draft comments on all revisions of a change, sorted by file, line and update time
+func (c *Client) ListDrafts(ctx context.Context, changeID string) ([]Comment, error) {
+	path := fmt.Sprintf("/changes/%s/drafts", url.PathEscape(changeID))
+
+	resp, err := c.client.R().SetContext(ctx).SetResult(map[string][]Comment{}).Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rawDrafts := *resp.Result().(*map[string][]Comment)
+
+	var result []Comment
+	for path, drafts := range rawDrafts {
+		for _, draft := range drafts {
+			draft.Path = path
+			result = append(result, draft)
+		}
+	}
+
+	// Order drafts like threads, as Gerrit returns them keyed by file
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Updated != b.Updated {
+			return a.Updated < b.Updated
+		}
+		return a.ID < b.ID
+	})
+
+	return result, nil
+}
+
+// UpdateDraft updates the message or resolution of a draft comment, keeping its position
+func (c *Client) UpdateDraft(ctx context.Context, changeID, draftID string, input UpdateDraftInput) (Comment, error) {
+	draft, err := c.findDraft(ctx, changeID, draftID)
+	if err != nil {
+		return Comment{}, err
+	}
+
+	if input.Message != nil {
+		draft.Message = *input.Message
+	}
+	if input.Unresolved != nil {
+		draft.Unresolved = *input.Unresolved
+	}
+
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetBody(draft).
+		SetResult(Comment{}).
+		Put(draftPath(changeID, draft))
+	if err != nil {
+		return Comment{}, err
+	}
+
+	updated := *resp.Result().(*Comment)
+	updated.Path = draft.Path
+
+	return updated, nil
+}
+
+// DeleteDraft deletes a draft comment
+func (c *Client) DeleteDraft(ctx context.Context, changeID, draftID string) error {
+	draft, err := c.findDraft(ctx, changeID, draftID)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.R().SetContext(ctx).Delete(draftPath(changeID, draft))
+
+	return err
+}
+
+// findDraft looks up a draft comment by ID, as drafts are addressed through the revision they belong to
+func (c *Client) findDraft(ctx context.Context, changeID, draftID string) (Comment, error) {
+	drafts, err := c.ListDrafts(ctx, changeID)
+	if err != nil {
+		return Comment{}, err
+	}
+
+	for _, draft := range drafts {
+		if draft.ID == draftID {
+			return draft, nil
+		}
+	}
+
+	return Comment{}, fmt.Errorf("%w: %s", ErrDraftNotFound, draftID)
+}
+
+// draftPath returns the REST path of a draft comment
+func draftPath(changeID string, draft Comment) string {
+	return fmt.Sprintf("/changes/%s/revisions/%s/drafts/%s",
+		url.PathEscape(changeID), strconv.Itoa(draft.PatchSet), url.PathEscape(draft.ID))
+}
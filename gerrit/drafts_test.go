@@ -0,0 +1,44 @@
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestListDrafts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`)]}'
+{
+  "src/b.go": [
+    {"id": "d4", "line": 3, "updated": "2024-01-01 10:00:00.000000000"}
+  ],
+  "src/a.go": [
+    {"id": "d3", "line": 10, "updated": "2024-01-01 09:00:00.000000000"},
+    {"id": "d2", "line": 2, "updated": "2024-01-01 11:00:00.000000000"},
+    {"id": "d1", "line": 2, "updated": "2024-01-01 11:00:00.000000000"},
+    {"id": "d0", "line": 2, "updated": "2024-01-01 08:00:00.000000000"}
+  ]
+}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(server.URL, "", "")
+	for i := 0; i < 5; i++ {
+		drafts, err := client.ListDrafts(context.Background(), "123")
+		if err != nil {
+			t.Fatalf("ListDrafts() error = %v", err)
+		}
+
+		var ids []string
+		for _, draft := range drafts {
+			ids = append(ids, draft.Path+"/"+draft.ID)
+		}
+
+		if want := []string{"src/a.go/d0", "src/a.go/d1", "src/a.go/d2", "src/a.go/d3", "src/b.go/d4"}; !reflect.DeepEqual(ids, want) {
+			t.Fatalf("ListDrafts() = %v, want %v", ids, want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package gerrit
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError represents an error response returned by the Gerrit REST API
+type APIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	Body       string
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("Gerrit API error: %s %s returned %d %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Body != "" {
+		msg += ": " + e.Body
+	}
+	return msg
+}
+
+// DryRunError is returned instead of sending a mutating request when dry-run mode is enabled
+type DryRunError struct {
+	Method string
+	URL    string
+	Body   string
+}
+
+// Error implements the error interface
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("dry run: %s %s was not sent", e.Method, e.URL)
+}
+
+// Request returns the request that would have been sent, formatted for display
+func (e *DryRunError) Request() string {
+	if e.Body == "" {
+		return fmt.Sprintf("%s %s", e.Method, e.URL)
+	}
+	return fmt.Sprintf("%s %s\n\n%s", e.Method, e.URL, e.Body)
+}
+
+// IsNotFound reports whether err is a Gerrit API error for a missing or invisible resource
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is a Gerrit API error caused by the current state of the resource (e.g. a closed change)
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsAuth reports whether err is a Gerrit API error caused by missing credentials or permissions
+func IsAuth(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized, http.StatusForbidden)
+}
+
+// hasStatus reports whether err is a Gerrit API error with one of the given status codes
+func hasStatus(err error, codes ...int) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	for _, code := range codes {
+		if apiErr.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
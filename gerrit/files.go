@@ -0,0 +1,106 @@
+package gerrit
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FileInfo represents a file modified in a revision
+type FileInfo struct {
+	Path          string `json:"path"`
+	Status        string `json:"status,omitempty"`
+	Binary        bool   `json:"binary,omitempty"`
+	OldPath       string `json:"old_path,omitempty"`
+	LinesInserted int    `json:"lines_inserted,omitempty"`
+	LinesDeleted  int    `json:"lines_deleted,omitempty"`
+	SizeDelta     int64  `json:"size_delta"`
+	Size          int64  `json:"size"`
+}
+
+// ListFiles lists the files modified in a revision of a change, compared to the given base patch set (0 compares to the parent).
+// Gerrit's magic files, such as /COMMIT_MSG and /MERGE_LIST, are left out as they are not in the repository.
+func (c *Client) ListFiles(ctx context.Context, changeID, revision string, base int) ([]FileInfo, error) {
+	if revision == "" {
+		revision = "current"
+	}
+
+	path := fmt.Sprintf("/changes/%s/revisions/%s/files", url.PathEscape(changeID), url.PathEscape(revision))
+
+	req := c.client.R().SetContext(ctx).SetResult(map[string]FileInfo{})
+	if base > 0 {
+		req.SetQueryParam("base", strconv.Itoa(base))
+	}
+
+	resp, err := req.Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rawFiles := *resp.Result().(*map[string]FileInfo)
+
+	result := make([]FileInfo, 0, len(rawFiles))
+	for path, file := range rawFiles {
+		if isMagicFile(path) {
+			continue
+		}
+
+		file.Path = path
+		if file.Status == "" {
+			file.Status = "M"
+		}
+		result = append(result, file)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+
+	return result, nil
+}
+
+// isMagicFile reports whether path is one of the files Gerrit adds to every revision, such as /COMMIT_MSG.
+// Their paths are the only ones starting with a slash.
+func isMagicFile(path string) bool {
+	return strings.HasPrefix(path, "/")
+}
+
+// GetFileContent gets the content of a file in a revision of a change. If parent is set,
+// the content of the file in the revision's parent commit is returned instead.
+func (c *Client) GetFileContent(ctx context.Context, changeID, revision, path string, parent bool) ([]byte, error) {
+	if revision == "" {
+		revision = "current"
+	}
+
+	endpoint := fmt.Sprintf("/changes/%s/revisions/%s/files/%s/content",
+		url.PathEscape(changeID), url.PathEscape(revision), url.PathEscape(path))
+
+	req := c.client.R().SetContext(ctx)
+	if parent {
+		req.SetQueryParam("parent", "1")
+	}
+
+	resp, err := req.Get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Gerrit returns file content base64 encoded
+	encoded, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	content, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode file content: %w", err)
+	}
+
+	return content, nil
+}
@@ -0,0 +1,37 @@
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestListFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`)]}'
+{
+  "/COMMIT_MSG": {"status": "A", "lines_inserted": 7},
+  "/MERGE_LIST": {"status": "A", "lines_inserted": 5},
+  "src/b.go": {"lines_inserted": 2, "lines_deleted": 1},
+  "src/a.go": {"status": "A", "lines_inserted": 10},
+  "docs/new.md": {"status": "R", "old_path": "docs/old.md"}
+}`))
+	}))
+	defer server.Close()
+
+	files, err := NewClientWithBaseURL(server.URL, "", "").ListFiles(context.Background(), "123", "", 0)
+	if err != nil {
+		t.Fatalf("ListFiles() error = %v", err)
+	}
+
+	want := []FileInfo{
+		{Path: "docs/new.md", Status: "R", OldPath: "docs/old.md"},
+		{Path: "src/a.go", Status: "A", LinesInserted: 10},
+		{Path: "src/b.go", Status: "M", LinesInserted: 2, LinesDeleted: 1},
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("ListFiles() = %+v, want %+v", files, want)
+	}
+}
@@ -0,0 +1,34 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrInvalidHook is returned when Gerrit serves something other than a hook script,
+// e.g. the HTML of a login page
+var ErrInvalidHook = errors.New("downloaded commit-msg hook is not a script")
+
+// GetCommitMsgHook downloads the commit-msg hook that adds a Change-Id footer to commit messages
+func (c *Client) GetCommitMsgHook(ctx context.Context) ([]byte, error) {
+	// The hook is served outside of the authenticated /a REST API
+	resp, err := c.client.R().SetContext(ctx).Get(c.baseURL + "/tools/hooks/commit-msg")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	hook, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if !bytes.HasPrefix(hook, []byte("#!")) {
+		return nil, ErrInvalidHook
+	}
+
+	return hook, nil
+}
@@ -0,0 +1,60 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// RebaseInput represents the options for rebasing a change
+type RebaseInput struct {
+	Base           string `json:"base,omitempty"`
+	AllowConflicts bool   `json:"allow_conflicts,omitempty"`
+}
+
+// MoveInput represents the options for moving a change to another branch
+type MoveInput struct {
+	DestinationBranch string `json:"destination_branch"`
+	Message           string `json:"message,omitempty"`
+}
+
+// SubmitChange submits a change
+func (c *Client) SubmitChange(ctx context.Context, changeID string) (Change, error) {
+	return c.postChange(ctx, changeID, "submit", map[string]any{})
+}
+
+// AbandonChange abandons a change with an optional message
+func (c *Client) AbandonChange(ctx context.Context, changeID, message string) (Change, error) {
+	return c.postChange(ctx, changeID, "abandon", map[string]any{"message": message})
+}
+
+// RestoreChange restores an abandoned change with an optional message
+func (c *Client) RestoreChange(ctx context.Context, changeID, message string) (Change, error) {
+	return c.postChange(ctx, changeID, "restore", map[string]any{"message": message})
+}
+
+// RebaseChange rebases a change onto the tip of its branch or onto the given base
+func (c *Client) RebaseChange(ctx context.Context, changeID string, input RebaseInput) (Change, error) {
+	return c.postChange(ctx, changeID, "rebase", input)
+}
+
+// MoveChange moves a change to another branch
+func (c *Client) MoveChange(ctx context.Context, changeID string, input MoveInput) (Change, error) {
+	return c.postChange(ctx, changeID, "move", input)
+}
+
+// postChange posts to a change action endpoint and returns the updated change
+func (c *Client) postChange(ctx context.Context, changeID, action string, body any) (Change, error) {
+	path := fmt.Sprintf("/changes/%s/%s", url.PathEscape(changeID), action)
+
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetBody(body).
+		SetResult(Change{}).
+		Post(path)
+	if err != nil {
+		return Change{}, err
+	}
+
+	return *resp.Result().(*Change), nil
+}
@@ -5,17 +5,42 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
+	"strings"
 
 	"resty.dev/v3"
 )
 
-// autoErrorMiddleware automatically returns an error for non-2xx responses
+// maxErrorBodySize limits how much of an error response body is kept
+const maxErrorBodySize = 64 * 1024
+
+// autoErrorMiddleware automatically returns an APIError for non-2xx responses
 func autoErrorMiddleware(c *resty.Client, r *resty.Response) error {
-	if r.StatusCode() < 200 || r.StatusCode() >= 300 {
-		return fmt.Errorf("Gerrit API error: %d %s", r.StatusCode(), r.Status())
+	if r.StatusCode() >= 200 && r.StatusCode() < 300 {
+		return nil
 	}
 
-	return nil
+	apiErr := &APIError{
+		StatusCode: r.StatusCode(),
+		Method:     r.Request.Method,
+		Path:       r.Request.URL,
+	}
+
+	if r.Request.RawRequest != nil {
+		apiErr.Path = r.Request.RawRequest.URL.Path
+	}
+
+	if r.Body != nil {
+		defer r.Body.Close()
+
+		// Gerrit explains most errors in a plain-text body
+		b, err := io.ReadAll(io.LimitReader(r.Body, maxErrorBodySize))
+		if err == nil {
+			apiErr.Body = strings.TrimSpace(string(b))
+		}
+	}
+
+	return apiErr
 }
 
 // autoParseMiddleware automatically parses the response body into the Result field of the request
@@ -46,3 +71,25 @@ func autoParseMiddleware(c *resty.Client, r *resty.Response) error {
 
 	return nil
 }
+
+// dryRunMiddleware aborts mutating requests, returning a DryRunError that describes them instead.
+// It must run after resty.PrepareRequestMiddleware, which builds the full URL.
+func dryRunMiddleware(c *resty.Client, r *resty.Request) error {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return nil
+	}
+
+	dryRun := &DryRunError{Method: r.Method, URL: r.URL}
+	if r.RawRequest != nil {
+		dryRun.URL = r.RawRequest.URL.String()
+	}
+	if r.Body != nil {
+		b, err := json.MarshalIndent(r.Body, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+		dryRun.Body = string(b)
+	}
+
+	return dryRun
+}
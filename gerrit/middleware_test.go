@@ -0,0 +1,73 @@
+package gerrit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Method+" "+r.URL.String())
+		w.Write([]byte(")]}'\n{\"_number\":123}"))
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL(server.URL+"/gerrit", "jane", "secret")
+	client.EnableDryRun()
+
+	tests := []struct {
+		name   string
+		send   func() error
+		method string
+		url    string
+		body   string
+	}{
+		{
+			name: "post with a body",
+			send: func() error {
+				_, err := client.AbandonChange(context.Background(), "I123", "obsolete")
+				return err
+			},
+			method: http.MethodPost,
+			url:    server.URL + "/gerrit/a/changes/I123/abandon",
+			body:   "{\n  \"message\": \"obsolete\"\n}",
+		},
+		{
+			name: "put with query parameters",
+			send: func() error {
+				_, err := client.client.R().SetQueryParam("notify", "NONE").Put("/changes/I123/topic")
+				return err
+			},
+			method: http.MethodPut,
+			url:    server.URL + "/gerrit/a/changes/I123/topic?notify=NONE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dryRun *DryRunError
+			if err := tt.send(); !errors.As(err, &dryRun) {
+				t.Fatalf("error = %v, want a DryRunError", err)
+			}
+
+			if dryRun.Method != tt.method || dryRun.URL != tt.url || dryRun.Body != tt.body {
+				t.Errorf("DryRunError = %s %s %q, want %s %s %q", dryRun.Method, dryRun.URL, dryRun.Body, tt.method, tt.url, tt.body)
+			}
+		})
+	}
+
+	if len(sent) != 0 {
+		t.Errorf("dry run sent requests: %v", sent)
+	}
+
+	if _, err := client.GetChange(context.Background(), "I123"); err != nil {
+		t.Fatalf("GetChange() error = %v", err)
+	}
+	if len(sent) != 1 {
+		t.Errorf("dry run did not send the GET request, sent: %v", sent)
+	}
+}
@@ -0,0 +1,43 @@
+package gerrit
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// QueryOptions represents the options for querying changes
+type QueryOptions struct {
+	// Limit is the maximum number of changes to return (0 uses the server default)
+	Limit int
+	// Start is the number of changes to skip, used for pagination
+	Start int
+	// Options are additional fields to include, e.g. LABELS, CURRENT_REVISION or DETAILED_ACCOUNTS
+	Options []string
+}
+
+// QueryChanges queries changes using Gerrit search operators (e.g. "status:open owner:self")
+func (c *Client) QueryChanges(ctx context.Context, query string, opts QueryOptions) ([]Change, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	if opts.Limit > 0 {
+		params.Set("n", strconv.Itoa(opts.Limit))
+	}
+	if opts.Start > 0 {
+		params.Set("S", strconv.Itoa(opts.Start))
+	}
+	for _, option := range opts.Options {
+		params.Add("o", option)
+	}
+
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetQueryParamsFromValues(params).
+		SetResult([]Change{}).
+		Get("/changes/")
+	if err != nil {
+		return nil, err
+	}
+
+	return *resp.Result().(*[]Change), nil
+}
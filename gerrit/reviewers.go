@@ -0,0 +1,119 @@
+package gerrit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Account represents a Gerrit account
+type Account struct {
+	AccountID int    `json:"_account_id"`
+	Name      string `json:"name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Username  string `json:"username,omitempty"`
+}
+
+// DisplayName returns the name and email of the account, or whichever of them is set
+func (a Account) DisplayName() string {
+	return displayName(a.Name, a.Email, a.Username)
+}
+
+// Reviewer represents a reviewer of a change and the votes they have cast
+type Reviewer struct {
+	Account
+	Approvals map[string]string `json:"approvals,omitempty"`
+}
+
+// Group represents a Gerrit group
+type Group struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// SuggestedReviewer represents an account or group suggested as a reviewer
+type SuggestedReviewer struct {
+	Account *Account `json:"account,omitempty"`
+	Group   *Group   `json:"group,omitempty"`
+	Count   int      `json:"count,omitempty"`
+}
+
+// AddReviewerInput represents a reviewer or CC to be added to a change
+type AddReviewerInput struct {
+	Reviewer string `json:"reviewer"`
+	State    string `json:"state,omitempty"`
+	Notify   string `json:"notify,omitempty"`
+}
+
+// AddReviewerResult represents the outcome of adding a reviewer or CC
+type AddReviewerResult struct {
+	Input     string     `json:"input"`
+	Reviewers []Reviewer `json:"reviewers,omitempty"`
+	CCs       []Reviewer `json:"ccs,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	Confirm   bool       `json:"confirm,omitempty"`
+}
+
+// ListReviewers lists the reviewers and CCs of a change
+func (c *Client) ListReviewers(ctx context.Context, changeID string) ([]Reviewer, error) {
+	path := fmt.Sprintf("/changes/%s/reviewers", url.PathEscape(changeID))
+
+	resp, err := c.client.R().SetContext(ctx).SetResult([]Reviewer{}).Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return *resp.Result().(*[]Reviewer), nil
+}
+
+// AddReviewer adds a reviewer or CC (state "CC") to a change
+func (c *Client) AddReviewer(ctx context.Context, changeID string, input AddReviewerInput) (AddReviewerResult, error) {
+	path := fmt.Sprintf("/changes/%s/reviewers", url.PathEscape(changeID))
+
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetBody(input).
+		SetResult(AddReviewerResult{}).
+		Post(path)
+	if err != nil {
+		return AddReviewerResult{}, err
+	}
+
+	result := *resp.Result().(*AddReviewerResult)
+	if result.Error != "" {
+		return result, errors.New(result.Error)
+	}
+
+	return result, nil
+}
+
+// RemoveReviewer removes a reviewer or CC from a change
+func (c *Client) RemoveReviewer(ctx context.Context, changeID, accountID string) error {
+	path := fmt.Sprintf("/changes/%s/reviewers/%s", url.PathEscape(changeID), url.PathEscape(accountID))
+
+	_, err := c.client.R().SetContext(ctx).Delete(path)
+
+	return err
+}
+
+// SuggestReviewers suggests reviewers for a change matching the query, limited to n results (0 uses the server default)
+func (c *Client) SuggestReviewers(ctx context.Context, changeID, query string, n int) ([]SuggestedReviewer, error) {
+	path := fmt.Sprintf("/changes/%s/suggest_reviewers", url.PathEscape(changeID))
+
+	req := c.client.R().SetContext(ctx).SetResult([]SuggestedReviewer{})
+	if query != "" {
+		req.SetQueryParam("q", query)
+	}
+	if n > 0 {
+		req.SetQueryParam("n", strconv.Itoa(n))
+	}
+
+	resp, err := req.Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return *resp.Result().(*[]SuggestedReviewer), nil
+}
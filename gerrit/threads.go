@@ -0,0 +1,135 @@
+package gerrit
+
+import (
+	"context"
+	"sort"
+)
+
+// Thread represents a comment thread: a root comment and all replies to it, oldest first.
+// As in the Gerrit UI, a thread is unresolved if and only if its last comment is unresolved.
+type Thread struct {
+	ID         string    `json:"id"`
+	Path       string    `json:"path"`
+	Line       int       `json:"line,omitempty"`
+	Range      *Range    `json:"range,omitempty"`
+	Side       string    `json:"side,omitempty"`
+	PatchSet   int       `json:"patch_set"`
+	Unresolved bool      `json:"unresolved"`
+	Updated    string    `json:"updated,omitempty"`
+	Comments   []Comment `json:"comments"`
+}
+
+// GetThreads gets all comment threads of a change, sorted by file, line and update time
+func (c *Client) GetThreads(ctx context.Context, changeID string) ([]Thread, error) {
+	comments, err := c.GetComments(ctx, changeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return BuildThreads(comments), nil
+}
+
+// GetUnresolvedThreads gets the unresolved comment threads of a change
+func (c *Client) GetUnresolvedThreads(ctx context.Context, changeID string) ([]Thread, error) {
+	threads, err := c.GetThreads(ctx, changeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Thread
+	for _, thread := range threads {
+		if thread.Unresolved {
+			result = append(result, thread)
+		}
+	}
+
+	return result, nil
+}
+
+// BuildThreads groups comments into threads by following their in_reply_to chains
+func BuildThreads(comments []Comment) []Thread {
+	byID := make(map[string]Comment, len(comments))
+	for _, comment := range comments {
+		byID[comment.ID] = comment
+	}
+
+	// rootOf follows the reply chain up to the first comment whose parent is unknown,
+	// and returns how many replies deep the comment is
+	rootOf := func(comment Comment) (Comment, int) {
+		seen := map[string]bool{comment.ID: true}
+		for comment.InReplyTo != "" {
+			parent, ok := byID[comment.InReplyTo]
+			if !ok || seen[parent.ID] {
+				break
+			}
+			seen[parent.ID] = true
+			comment = parent
+		}
+		return comment, len(seen) - 1
+	}
+
+	depths := make(map[string]int, len(comments))
+
+	threadsByRoot := map[string]*Thread{}
+	var roots []string
+	for _, comment := range comments {
+		root, depth := rootOf(comment)
+		depths[comment.ID] = depth
+
+		thread, ok := threadsByRoot[root.ID]
+		if !ok {
+			thread = &Thread{
+				ID:       root.ID,
+				Path:     root.Path,
+				Line:     root.Line,
+				Range:    root.Range,
+				Side:     root.Side,
+				PatchSet: root.PatchSet,
+			}
+			threadsByRoot[root.ID] = thread
+			roots = append(roots, root.ID)
+		}
+
+		thread.Comments = append(thread.Comments, comment)
+	}
+
+	threads := make([]Thread, 0, len(roots))
+	for _, id := range roots {
+		thread := threadsByRoot[id]
+
+		// Gerrit timestamps are fixed-width, so they sort correctly as strings. Comments with the
+		// same timestamp keep replies after their parents and are otherwise ordered by ID, so the
+		// last comment, which decides the thread's state, does not depend on the input order.
+		sort.SliceStable(thread.Comments, func(i, j int) bool {
+			a, b := thread.Comments[i], thread.Comments[j]
+			if a.Updated != b.Updated {
+				return a.Updated < b.Updated
+			}
+			if depths[a.ID] != depths[b.ID] {
+				return depths[a.ID] < depths[b.ID]
+			}
+			return a.ID < b.ID
+		})
+
+		last := thread.Comments[len(thread.Comments)-1]
+		thread.Unresolved = last.Unresolved
+		thread.Updated = last.Updated
+
+		threads = append(threads, *thread)
+	}
+
+	sort.SliceStable(threads, func(i, j int) bool {
+		if threads[i].Path != threads[j].Path {
+			return threads[i].Path < threads[j].Path
+		}
+		if threads[i].Line != threads[j].Line {
+			return threads[i].Line < threads[j].Line
+		}
+		if threads[i].Updated != threads[j].Updated {
+			return threads[i].Updated < threads[j].Updated
+		}
+		return threads[i].ID < threads[j].ID
+	})
+
+	return threads
+}
@@ -0,0 +1,113 @@
+package gerrit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildThreads(t *testing.T) {
+	const (
+		t1 = "2024-01-01 10:00:00.000000000"
+		t2 = "2024-01-01 11:00:00.000000000"
+		t3 = "2024-01-01 12:00:00.000000000"
+	)
+
+	tests := []struct {
+		name       string
+		comments   []Comment
+		ids        [][]string
+		unresolved []bool
+	}{
+		{
+			name: "reply chain",
+			comments: []Comment{
+				{ID: "c3", InReplyTo: "c2", Path: "a.go", Line: 1, Updated: t3, Unresolved: false},
+				{ID: "c1", Path: "a.go", Line: 1, Updated: t1, Unresolved: true},
+				{ID: "c2", InReplyTo: "c1", Path: "a.go", Line: 1, Updated: t2, Unresolved: true},
+			},
+			ids:        [][]string{{"c1", "c2", "c3"}},
+			unresolved: []bool{false},
+		},
+		{
+			name: "orphan reply starts its own thread",
+			comments: []Comment{
+				{ID: "c1", Path: "a.go", Line: 1, Updated: t1, Unresolved: false},
+				{ID: "c2", InReplyTo: "missing", Path: "a.go", Line: 5, Updated: t2, Unresolved: true},
+				{ID: "c3", InReplyTo: "c2", Path: "a.go", Line: 5, Updated: t3, Unresolved: true},
+			},
+			ids:        [][]string{{"c1"}, {"c2", "c3"}},
+			unresolved: []bool{false, true},
+		},
+		{
+			name: "reply with the same timestamp comes after its parent",
+			comments: []Comment{
+				{ID: "a", InReplyTo: "b", Path: "a.go", Line: 1, Updated: t1, Unresolved: false},
+				{ID: "b", Path: "a.go", Line: 1, Updated: t1, Unresolved: true},
+			},
+			ids:        [][]string{{"b", "a"}},
+			unresolved: []bool{false},
+		},
+		{
+			name: "sibling replies with the same timestamp are ordered by ID",
+			comments: []Comment{
+				{ID: "r2", InReplyTo: "root", Path: "a.go", Updated: t2, Unresolved: true},
+				{ID: "root", Path: "a.go", Updated: t1, Unresolved: true},
+				{ID: "r1", InReplyTo: "root", Path: "a.go", Updated: t2, Unresolved: false},
+			},
+			ids:        [][]string{{"root", "r1", "r2"}},
+			unresolved: []bool{true},
+		},
+		{
+			name: "threads are sorted by path, line, update time and ID",
+			comments: []Comment{
+				{ID: "z", Path: "b.go", Line: 1, Updated: t1},
+				{ID: "y", Path: "a.go", Line: 2, Updated: t1},
+				{ID: "x", Path: "a.go", Line: 2, Updated: t1},
+				{ID: "w", Path: "a.go", Line: 1, Updated: t3},
+			},
+			ids:        [][]string{{"w"}, {"x"}, {"y"}, {"z"}},
+			unresolved: []bool{false, false, false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			threads := BuildThreads(tt.comments)
+
+			var ids [][]string
+			var unresolved []bool
+			for _, thread := range threads {
+				var threadIDs []string
+				for _, comment := range thread.Comments {
+					threadIDs = append(threadIDs, comment.ID)
+				}
+				ids = append(ids, threadIDs)
+				unresolved = append(unresolved, thread.Unresolved)
+
+				if thread.ID != thread.Comments[0].ID {
+					t.Errorf("thread ID = %q, want the ID of its first comment %q", thread.ID, thread.Comments[0].ID)
+				}
+			}
+
+			if !reflect.DeepEqual(ids, tt.ids) {
+				t.Errorf("BuildThreads() comment IDs = %v, want %v", ids, tt.ids)
+			}
+			if !reflect.DeepEqual(unresolved, tt.unresolved) {
+				t.Errorf("BuildThreads() unresolved = %v, want %v", unresolved, tt.unresolved)
+			}
+		})
+	}
+}
+
+func TestBuildThreadsIsDeterministic(t *testing.T) {
+	comments := []Comment{
+		{ID: "root", Path: "a.go", Line: 1, Updated: "2024-01-01 10:00:00.000000000", Unresolved: true},
+		{ID: "r1", InReplyTo: "root", Path: "a.go", Line: 1, Updated: "2024-01-01 11:00:00.000000000", Unresolved: false},
+		{ID: "r2", InReplyTo: "root", Path: "a.go", Line: 1, Updated: "2024-01-01 11:00:00.000000000", Unresolved: true},
+	}
+	reversed := []Comment{comments[2], comments[1], comments[0]}
+
+	if a, b := BuildThreads(comments), BuildThreads(reversed); !reflect.DeepEqual(a, b) {
+		t.Errorf("BuildThreads() depends on the input order:\n%+v\n%+v", a, b)
+	}
+}
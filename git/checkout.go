@@ -0,0 +1,94 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Fetch fetches a ref (e.g. refs/changes/45/12345/3) from remote, a remote name or URL
+func Fetch(ctx context.Context, cwd, remote, ref string) error {
+	if err := checkArgument("remote", remote); err != nil {
+		return err
+	}
+	if err := checkArgument("ref", ref); err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "fetch", remote, ref)
+	cmd.Dir = cwd
+	// Never prompt: stdin and stdout belong to the MCP transport
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil {
+		return fmt.Errorf("git fetch failed: %w\n%s", err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// CheckCheckout verifies that branch, commit and the optional worktree path are safe to pass to
+// git: none of them may look like an option and branch must be a valid branch name
+func CheckCheckout(ctx context.Context, path, branch, commit string) error {
+	for _, arg := range []struct{ name, value string }{
+		{"worktree", path},
+		{"branch", branch},
+		{"commit", commit},
+	} {
+		if err := checkArgument(arg.name, arg.value); err != nil {
+			return err
+		}
+	}
+
+	if err := exec.CommandContext(ctx, "git", "check-ref-format", "--branch", branch).Run(); err != nil {
+		return fmt.Errorf("%w: %q is not a valid branch name", ErrInvalidArgument, branch)
+	}
+
+	return nil
+}
+
+// CheckoutBranch creates a new branch at commit and checks it out
+func CheckoutBranch(ctx context.Context, cwd, branch, commit string) error {
+	if err := CheckCheckout(ctx, "", branch, commit); err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "checkout", "-b", branch, commit)
+	cmd.Dir = cwd
+
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil {
+		return fmt.Errorf("git checkout failed: %w\n%s", err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// AddWorktree creates a new branch at commit and checks it out in a separate worktree at path
+func AddWorktree(ctx context.Context, cwd, path, branch, commit string) error {
+	if err := CheckCheckout(ctx, path, branch, commit); err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "worktree", "add", "-b", branch, path, commit)
+	cmd.Dir = cwd
+
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil {
+		return fmt.Errorf("git worktree add failed: %w\n%s", err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
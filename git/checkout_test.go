@@ -0,0 +1,47 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+)
+
+func TestFetch(t *testing.T) {
+	gerrit, local := t.TempDir(), t.TempDir()
+	for _, step := range []struct {
+		dir  string
+		args []string
+	}{
+		{gerrit, []string{"init", "--quiet"}},
+		{gerrit, []string{"-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "--quiet", "--allow-empty", "-m", "change"}},
+		{gerrit, []string{"update-ref", "refs/changes/45/12345/1", "HEAD"}},
+		{local, []string{"init", "--quiet"}},
+	} {
+		cmd := exec.Command("git", step.args...)
+		cmd.Dir = step.dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", step.args, err, output)
+		}
+	}
+
+	if err := Fetch(context.Background(), local, gerrit, "refs/changes/45/12345/1"); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", "FETCH_HEAD^{commit}")
+	cmd.Dir = local
+	if err := cmd.Run(); err != nil {
+		t.Errorf("FETCH_HEAD is not a commit after Fetch(): %v", err)
+	}
+
+	if err := Fetch(context.Background(), local, "--upload-pack=touch pwned", "refs/changes/45/12345/1"); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Fetch() with an option as remote error = %v, want %v", err, ErrInvalidArgument)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := Fetch(ctx, local, gerrit, "refs/changes/45/12345/1"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Fetch() with a cancelled context error = %v, want %v", err, context.Canceled)
+	}
+}
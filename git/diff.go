@@ -0,0 +1,144 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// hunkHeaderPattern matches unified diff hunk headers such as @@ -12,3 +12,4 @@
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// Hunk represents a changed region between two versions of a file.
+// OldStart and NewStart are 1-based; a zero count means lines were only added or only removed.
+type Hunk struct {
+	OldStart, OldCount int
+	NewStart, NewCount int
+}
+
+// LineStatus describes what happened to a line between two versions of a file
+type LineStatus string
+
+const (
+	// LineUnchanged means the line is still at the same line number
+	LineUnchanged LineStatus = "unchanged"
+	// LineMoved means the line is unchanged but now at a different line number
+	LineMoved LineStatus = "moved"
+	// LineDeleted means the line was removed or modified
+	LineDeleted LineStatus = "deleted"
+)
+
+// GetRoot returns the top-level directory of the repository containing cwd
+func GetRoot(cwd string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	cmd.Dir = cwd
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to find git repository root: %w", err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// DiffLines computes the changed regions between two versions of a file using git diff
+func DiffLines(oldContent, newContent []byte) ([]Hunk, error) {
+	dir, err := os.MkdirTemp("", "gerry-diff-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	oldPath, newPath := filepath.Join(dir, "old"), filepath.Join(dir, "new")
+	if err := os.WriteFile(oldPath, oldContent, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := os.WriteFile(newPath, newContent, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	// External diff drivers and textconv filters from the user's config could change the output
+	cmd := exec.Command("git", "diff", "--no-index", "--no-color", "--no-ext-diff", "--no-textconv", "--unified=0", oldPath, newPath)
+	output, err := cmd.Output()
+	if err != nil {
+		// git diff --no-index exits with 1 when the files differ
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+			return nil, fmt.Errorf("failed to diff files: %w", err)
+		}
+	}
+
+	return parseHunks(string(output)), nil
+}
+
+// parseHunks extracts the hunk headers from unified diff output
+func parseHunks(diff string) []Hunk {
+	var hunks []Hunk
+	for _, line := range strings.Split(diff, "\n") {
+		match := hunkHeaderPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		hunks = append(hunks, Hunk{
+			OldStart: atoiDefault(match[1], 0),
+			OldCount: atoiDefault(match[2], 1),
+			NewStart: atoiDefault(match[3], 0),
+			NewCount: atoiDefault(match[4], 1),
+		})
+	}
+
+	return hunks
+}
+
+// MapLine maps a 1-based line of the old version of a file to the new version using the hunks
+// from DiffLines. For deleted lines, the returned line is where the region they were in now starts.
+func MapLine(hunks []Hunk, line int) (int, LineStatus) {
+	offset := 0
+	for _, hunk := range hunks {
+		// Pure insertions are reported after OldStart, removals and modifications starting at it
+		oldEnd := hunk.OldStart + hunk.OldCount
+		if hunk.OldCount == 0 {
+			if line <= hunk.OldStart {
+				break
+			}
+			offset += hunk.NewCount
+			continue
+		}
+
+		if line < hunk.OldStart {
+			break
+		}
+
+		if line < oldEnd {
+			// A pure removal reports the line before the removed region as its NewStart
+			start := hunk.NewStart
+			if hunk.NewCount == 0 {
+				start++
+			}
+			return max(start, 1), LineDeleted
+		}
+
+		offset += hunk.NewCount - hunk.OldCount
+	}
+
+	if offset == 0 {
+		return line, LineUnchanged
+	}
+
+	return line + offset, LineMoved
+}
+
+// atoiDefault parses s as an integer, returning fallback if s is empty or invalid
+func atoiDefault(s string, fallback int) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fallback
+	}
+	return n
+}
@@ -0,0 +1,131 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseHunks(t *testing.T) {
+	tests := []struct {
+		name string
+		diff string
+		want []Hunk
+	}{
+		{
+			name: "no changes",
+			diff: "",
+			want: nil,
+		},
+		{
+			name: "counts default to one",
+			diff: "diff --git a/old b/new\n--- a/old\n+++ b/new\n@@ -3 +3 @@\n-a\n+b\n",
+			want: []Hunk{{OldStart: 3, OldCount: 1, NewStart: 3, NewCount: 1}},
+		},
+		{
+			name: "insertion and removal",
+			diff: "@@ -2,0 +3,2 @@ func main() {\n+x\n+y\n@@ -10,3 +11,0 @@\n-a\n-b\n-c\n",
+			want: []Hunk{
+				{OldStart: 2, OldCount: 0, NewStart: 3, NewCount: 2},
+				{OldStart: 10, OldCount: 3, NewStart: 11, NewCount: 0},
+			},
+		},
+		{
+			name: "content lines that look like headers are ignored",
+			diff: "@@ -1 +1 @@\n-@@ -5 +5 @@\n+ @@ -6 +6 @@\n",
+			want: []Hunk{{OldStart: 1, OldCount: 1, NewStart: 1, NewCount: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseHunks(tt.diff); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseHunks() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapLine(t *testing.T) {
+	hunks := []Hunk{
+		// two lines inserted after line 2
+		{OldStart: 2, OldCount: 0, NewStart: 3, NewCount: 2},
+		// lines 5-6 replaced by a single line
+		{OldStart: 5, OldCount: 2, NewStart: 7, NewCount: 1},
+		// lines 10-12 removed, so line 13 is now line 11
+		{OldStart: 10, OldCount: 3, NewStart: 10, NewCount: 0},
+	}
+
+	tests := []struct {
+		name       string
+		hunks      []Hunk
+		line       int
+		wantLine   int
+		wantStatus LineStatus
+	}{
+		{name: "no hunks", hunks: nil, line: 7, wantLine: 7, wantStatus: LineUnchanged},
+		{name: "before all hunks", hunks: hunks, line: 1, wantLine: 1, wantStatus: LineUnchanged},
+		{name: "line an insertion follows", hunks: hunks, line: 2, wantLine: 2, wantStatus: LineUnchanged},
+		{name: "after an insertion", hunks: hunks, line: 3, wantLine: 5, wantStatus: LineMoved},
+		{name: "modified line", hunks: hunks, line: 5, wantLine: 7, wantStatus: LineDeleted},
+		{name: "last modified line", hunks: hunks, line: 6, wantLine: 7, wantStatus: LineDeleted},
+		{name: "after a modification", hunks: hunks, line: 7, wantLine: 8, wantStatus: LineMoved},
+		{name: "first removed line", hunks: hunks, line: 10, wantLine: 11, wantStatus: LineDeleted},
+		{name: "removed line", hunks: hunks, line: 11, wantLine: 11, wantStatus: LineDeleted},
+		{name: "after a removal", hunks: hunks, line: 13, wantLine: 11, wantStatus: LineMoved},
+		{
+			name:       "offsets that cancel out",
+			hunks:      []Hunk{{OldStart: 1, OldCount: 0, NewStart: 2, NewCount: 1}, {OldStart: 3, OldCount: 1, NewStart: 3, NewCount: 0}},
+			line:       5,
+			wantLine:   5,
+			wantStatus: LineUnchanged,
+		},
+		{
+			name:       "removal at the start of the file",
+			hunks:      []Hunk{{OldStart: 1, OldCount: 2, NewStart: 0, NewCount: 0}},
+			line:       1,
+			wantLine:   1,
+			wantStatus: LineDeleted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, status := MapLine(tt.hunks, tt.line)
+			if line != tt.wantLine || status != tt.wantStatus {
+				t.Errorf("MapLine(%d) = (%d, %s), want (%d, %s)", tt.line, line, status, tt.wantLine, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestDiffLinesRemoval(t *testing.T) {
+	hunks, err := DiffLines([]byte("a\nb\nc\nd\ne\n"), []byte("a\nb\ne\n"))
+	if err != nil {
+		t.Fatalf("DiffLines() error = %v", err)
+	}
+
+	// c and d are gone, e is now line 3, where the removed region was
+	for line, want := range map[int]int{3: 3, 4: 3} {
+		if got, status := MapLine(hunks, line); got != want || status != LineDeleted {
+			t.Errorf("MapLine(%d) = (%d, %s), want (%d, %s)", line, got, status, want, LineDeleted)
+		}
+	}
+	if got, status := MapLine(hunks, 5); got != 3 || status != LineMoved {
+		t.Errorf("MapLine(5) = (%d, %s), want (3, %s)", got, status, LineMoved)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	hunks, err := DiffLines([]byte("a\nb\nc\nd\n"), []byte("a\nx\ny\nc\nd\ne\n"))
+	if err != nil {
+		t.Fatalf("DiffLines() error = %v", err)
+	}
+
+	want := []Hunk{
+		{OldStart: 2, OldCount: 1, NewStart: 2, NewCount: 2},
+		{OldStart: 4, OldCount: 0, NewStart: 6, NewCount: 1},
+	}
+	if !reflect.DeepEqual(hunks, want) {
+		t.Errorf("DiffLines() = %+v, want %+v", hunks, want)
+	}
+}
@@ -2,70 +2,112 @@
 package git
 
 import (
+	"errors"
 	"fmt"
 	"os/exec"
 	"regexp"
 	"strings"
 )
 
+// changeIDPattern matches the Change-Id footer of a commit message
+var changeIDPattern = regexp.MustCompile(`Change-Id: (I[a-f0-9]{40})`)
+
+// ErrInvalidArgument is returned for revisions, refs and paths that git would parse as options
+var ErrInvalidArgument = errors.New("invalid argument")
+
+// StackCommit represents a local commit in a stack of changes
+type StackCommit struct {
+	Commit   string `json:"commit"`
+	Subject  string `json:"subject"`
+	ChangeID string `json:"changeId,omitempty"`
+}
+
 // GetChangeIDFromCommit gets Change-Id from current git commit
 func GetChangeIDFromCommit(cwd string) (string, error) {
-	cmd := exec.Command("git", "log", "-1", "--format=%B")
+	return GetChangeIDFromRevision(cwd, "HEAD")
+}
+
+// GetChangeIDFromRevision gets Change-Id from the commit at the given revision (e.g. HEAD~2 or a SHA)
+func GetChangeIDFromRevision(cwd, revision string) (string, error) {
+	if revision == "" {
+		revision = "HEAD"
+	}
+
+	if err := checkArgument("revision", revision); err != nil {
+		return "", err
+	}
+
+	cmd := exec.Command("git", "log", "-1", "--format=%B", revision, "--")
 	cmd.Dir = cwd
 
 	output, err := cmd.Output()
 	if err != nil {
-		return "", fmt.Errorf("failed to get git commit message: %w", err)
+		return "", fmt.Errorf("failed to get git commit message for %s: %w", revision, err)
 	}
 
-	message := string(output)
-	re := regexp.MustCompile(`Change-Id: (I[a-f0-9]{40})`)
-	match := re.FindStringSubmatch(message)
+	return parseChangeID(string(output)), nil
+}
 
-	if match == nil {
-		return "", nil
+// GetStack lists the commits between base and HEAD, newest first, with their Change-Ids.
+// If base is empty, the merge-base with the upstream of the current branch is used.
+func GetStack(cwd, base string) ([]StackCommit, error) {
+	if base == "" {
+		cmd := exec.Command("git", "merge-base", "HEAD", "@{upstream}")
+		cmd.Dir = cwd
+
+		output, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to find merge-base with upstream (is an upstream branch configured?): %w", err)
+		}
+
+		base = strings.TrimSpace(string(output))
 	}
 
-	return match[1], nil
-}
+	if err := checkArgument("base", base); err != nil {
+		return nil, err
+	}
 
-// GetHostFromRemote extracts host from git remote URL
-func GetHostFromRemote(cwd string) (string, error) {
-	cmd := exec.Command("git", "remote", "get-url", "origin")
+	// Fields are separated by NUL and records by RS, as commit messages may contain newlines
+	cmd := exec.Command("git", "log", "--format=%H%x00%s%x00%B%x1e", base+"..HEAD", "--")
 	cmd.Dir = cwd
 
 	output, err := cmd.Output()
 	if err != nil {
-		return "", fmt.Errorf("failed to get git remote URL: %w", err)
+		return nil, fmt.Errorf("failed to list commits since %s: %w", base, err)
 	}
 
-	remoteURL := strings.TrimSpace(string(output))
+	var stack []StackCommit
+	for _, record := range strings.Split(string(output), "\x1e") {
+		fields := strings.SplitN(strings.TrimSpace(record), "\x00", 3)
+		if len(fields) < 3 {
+			continue
+		}
 
-	// Parse SSH URL: ssh://user@host:port/project or git@host:project
-	// Parse HTTPS URL: https://host/project
-	var host string
+		stack = append(stack, StackCommit{
+			Commit:   fields[0],
+			Subject:  fields[1],
+			ChangeID: parseChangeID(fields[2]),
+		})
+	}
 
-	if strings.HasPrefix(remoteURL, "ssh://") {
-		re := regexp.MustCompile(`ssh://(?:[^@]+@)?([^:/]+)`)
-		if match := re.FindStringSubmatch(remoteURL); match != nil {
-			host = match[1]
-		}
-	} else if strings.HasPrefix(remoteURL, "https://") {
-		re := regexp.MustCompile(`https://([^/]+)`)
-		if match := re.FindStringSubmatch(remoteURL); match != nil {
-			host = match[1]
-		}
-	} else if strings.Contains(remoteURL, "@") {
-		// git@host:project format
-		re := regexp.MustCompile(`[^@]+@([^:]+):`)
-		if match := re.FindStringSubmatch(remoteURL); match != nil {
-			host = match[1]
-		}
+	return stack, nil
+}
+
+// parseChangeID extracts the Change-Id from a commit message
+func parseChangeID(message string) string {
+	match := changeIDPattern.FindStringSubmatch(message)
+	if match == nil {
+		return ""
 	}
 
-	if host == "" {
-		return "", fmt.Errorf("could not parse host from git remote URL: %s", remoteURL)
+	return match[1]
+}
+
+// checkArgument rejects a user-supplied value that git would parse as an option
+func checkArgument(name, value string) error {
+	if strings.HasPrefix(value, "-") {
+		return fmt.Errorf("%w: %s must not start with '-' (got %q)", ErrInvalidArgument, name, value)
 	}
 
-	return host, nil
+	return nil
 }
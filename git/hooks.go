@@ -0,0 +1,141 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ErrOperationInProgress is returned when a merge, rebase or similar operation is in progress
+var ErrOperationInProgress = errors.New("an operation is in progress")
+
+// inProgressMarkers maps the files git keeps while an operation is in progress to the operation
+var inProgressMarkers = []struct{ path, operation string }{
+	{"MERGE_HEAD", "merge"},
+	{"rebase-merge", "rebase"},
+	{"rebase-apply", "rebase"},
+	{"CHERRY_PICK_HEAD", "cherry-pick"},
+	{"REVERT_HEAD", "revert"},
+}
+
+// GetHookPath returns the path of a git hook, honouring core.hooksPath and linked worktrees
+func GetHookPath(cwd, name string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--git-path", "hooks/"+name)
+	cmd.Dir = cwd
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to find git hooks directory: %w", err)
+	}
+
+	path := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(cwd, path)
+	}
+
+	return filepath.Abs(path)
+}
+
+// HasHook reports whether an executable hook with the given name is installed
+func HasHook(cwd, name string) (bool, error) {
+	path, err := GetHookPath(cwd, name)
+	if err != nil {
+		return false, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return info.Mode().IsRegular() && info.Mode().Perm()&0o111 != 0, nil
+}
+
+// InstallHook writes an executable hook with the given name and returns its path
+func InstallHook(cwd, name string, content []byte) (string, error) {
+	path, err := GetHookPath(cwd, name)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create git hooks directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, content, 0o755); err != nil {
+		return "", fmt.Errorf("failed to write %s hook: %w", name, err)
+	}
+
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(path, 0o755); err != nil {
+		return "", fmt.Errorf("failed to make %s hook executable: %w", name, err)
+	}
+
+	return path, nil
+}
+
+// AmendArgs returns the git arguments that amend HEAD without changing its tree, so that only the
+// message is rewritten and staged changes stay staged
+func AmendArgs() []string {
+	return []string{"commit", "--amend", "--only", "--no-edit", "--allow-empty"}
+}
+
+// CheckAmend verifies that HEAD can be amended: no merge, rebase, cherry-pick or revert may be in progress
+func CheckAmend(cwd string) error {
+	for _, marker := range inProgressMarkers {
+		cmd := exec.Command("git", "rev-parse", "--git-path", marker.path)
+		cmd.Dir = cwd
+
+		output, err := cmd.Output()
+		if err != nil {
+			return fmt.Errorf("failed to find git directory: %w", err)
+		}
+
+		path := strings.TrimSpace(string(output))
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(cwd, path)
+		}
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%w: a %s is in progress, finish or abort it first", ErrOperationInProgress, marker.operation)
+		}
+	}
+
+	return nil
+}
+
+// AmendChangeID amends the message of HEAD so the commit-msg hook adds a Change-Id, and returns the
+// Change-Id. HEAD is left untouched if it already has one.
+func AmendChangeID(cwd string) (string, error) {
+	changeID, err := GetChangeIDFromCommit(cwd)
+	if err != nil || changeID != "" {
+		return changeID, err
+	}
+
+	if err := CheckAmend(cwd); err != nil {
+		return "", err
+	}
+
+	cmd := exec.Command("git", AmendArgs()...)
+	cmd.Dir = cwd
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("git commit --amend failed: %w\n%s", err, strings.TrimSpace(string(output)))
+	}
+
+	changeID, err = GetChangeIDFromCommit(cwd)
+	if err != nil {
+		return "", err
+	}
+
+	if changeID == "" {
+		return "", fmt.Errorf("the commit-msg hook did not add a Change-Id to HEAD")
+	}
+
+	return changeID, nil
+}
@@ -0,0 +1,72 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAmendChangeID(t *testing.T) {
+	dir := t.TempDir()
+	for _, env := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(env, "Test")
+	}
+	for _, env := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(env, "test@example.com")
+	}
+
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+
+	git("init", "--quiet")
+	git("commit", "--quiet", "--allow-empty", "-m", "initial")
+
+	hook := "#!/bin/sh\nprintf '\\nChange-Id: I0123456789abcdef0123456789abcdef01234567\\n' >> \"$1\"\n"
+	if _, err := InstallHook(dir, "commit-msg", []byte(hook)); err != nil {
+		t.Fatalf("InstallHook() error = %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "staged.txt"), []byte("staged\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", "staged.txt")
+
+	// A merge in progress blocks the amend
+	mergeHead := filepath.Join(dir, ".git", "MERGE_HEAD")
+	if err := os.WriteFile(mergeHead, []byte(git("rev-parse", "HEAD")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AmendChangeID(dir); !errors.Is(err, ErrOperationInProgress) {
+		t.Fatalf("AmendChangeID() during a merge error = %v, want %v", err, ErrOperationInProgress)
+	}
+	if err := os.Remove(mergeHead); err != nil {
+		t.Fatal(err)
+	}
+
+	changeID, err := AmendChangeID(dir)
+	if err != nil {
+		t.Fatalf("AmendChangeID() error = %v", err)
+	}
+	if changeID != "I0123456789abcdef0123456789abcdef01234567" {
+		t.Errorf("AmendChangeID() = %q", changeID)
+	}
+
+	// Staged changes are not folded into the amended commit
+	if files := git("show", "--name-only", "--format=", "HEAD"); files != "" {
+		t.Errorf("amended HEAD changes %q, want no files", files)
+	}
+	if staged := git("diff", "--cached", "--name-only"); staged != "staged.txt" {
+		t.Errorf("staged files after amend = %q, want staged.txt", staged)
+	}
+}
@@ -0,0 +1,189 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pushedChangePattern matches the change URLs Gerrit prints after a push, e.g.
+// "remote:   https://review.example.com/c/project/+/12345 Fix the thing [NEW]". Some servers also
+// print the patch set, e.g. ".../+/12345/2"
+var pushedChangePattern = regexp.MustCompile(`(?m)^remote:\s+(https?://\S+/\+/(\d+)(?:/(\d+))?)/?\s*(.*?)\s*$`)
+
+// PushOptions represents the Gerrit push options applied to a push for review
+type PushOptions struct {
+	Topic           string
+	Hashtags        []string
+	Reviewers       []string
+	CC              []string
+	WIP             bool
+	Ready           bool
+	Message         string
+	Notify          string
+	PublishComments bool
+}
+
+// PushedChange represents a change created or updated by a push for review
+type PushedChange struct {
+	URL      string `json:"url"`
+	Number   int    `json:"number"`
+	PatchSet int    `json:"patchSet,omitempty"`
+	Subject  string `json:"subject,omitempty"`
+	New      bool   `json:"new"`
+}
+
+// PushArgs returns the git push arguments that push commit to refs/for/branch on remote with the given options
+func PushArgs(remote, commit, branch string, opts PushOptions) []string {
+	if commit == "" {
+		commit = "HEAD"
+	}
+
+	args := []string{"push"}
+
+	option := func(value string) {
+		args = append(args, "-o", value)
+	}
+	if opts.Topic != "" {
+		option("topic=" + opts.Topic)
+	}
+	for _, hashtag := range opts.Hashtags {
+		option("hashtag=" + hashtag)
+	}
+	for _, reviewer := range opts.Reviewers {
+		option("r=" + reviewer)
+	}
+	for _, cc := range opts.CC {
+		option("cc=" + cc)
+	}
+	if opts.WIP {
+		option("wip")
+	}
+	if opts.Ready {
+		option("ready")
+	}
+	if opts.Message != "" {
+		option("m=" + encodePushMessage(opts.Message))
+	}
+	if opts.Notify != "" {
+		option("notify=" + opts.Notify)
+	}
+	if opts.PublishComments {
+		option("publish-comments")
+	}
+
+	return append(args, remote, fmt.Sprintf("%s:refs/for/%s", commit, branch))
+}
+
+// CheckPush verifies that remote, commit and branch are safe to pass to git push: none of them may
+// look like an option, commit must name an existing commit and branch must be a valid branch name
+func CheckPush(ctx context.Context, cwd, remote, commit, branch string) error {
+	if commit == "" {
+		commit = "HEAD"
+	}
+
+	for _, arg := range []struct{ name, value string }{
+		{"remote", remote},
+		{"commit", commit},
+		{"branch", branch},
+	} {
+		if err := checkArgument(arg.name, arg.value); err != nil {
+			return err
+		}
+	}
+
+	if err := exec.CommandContext(ctx, "git", "check-ref-format", "--branch", branch).Run(); err != nil {
+		return fmt.Errorf("%w: %q is not a valid branch name", ErrInvalidArgument, branch)
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", commit+"^{commit}")
+	cmd.Dir = cwd
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w: %q is not a commit", ErrInvalidArgument, commit)
+	}
+
+	return nil
+}
+
+// Push pushes commit to refs/for/branch on remote for review and returns the changes Gerrit reports
+func Push(ctx context.Context, cwd, remote, commit, branch string, opts PushOptions) ([]PushedChange, error) {
+	if err := CheckPush(ctx, cwd, remote, commit, branch); err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, "git", PushArgs(remote, commit, branch, opts)...)
+	cmd.Dir = cwd
+	// Never prompt: stdin and stdout belong to the MCP transport
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("git push failed: %w\n%s", err, strings.TrimSpace(string(output)))
+	}
+
+	return parsePushOutput(string(output)), nil
+}
+
+// GetUpstreamBranch returns the name of the branch the current branch tracks on its remote, as
+// configured by branch.<name>.merge
+func GetUpstreamBranch(cwd string) (string, error) {
+	cmd := exec.Command("git", "symbolic-ref", "--quiet", "--short", "HEAD")
+	cmd.Dir = cwd
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine upstream branch: HEAD is not on a branch")
+	}
+	current := strings.TrimSpace(string(output))
+
+	cmd = exec.Command("git", "config", "--get", "branch."+current+".merge")
+	cmd.Dir = cwd
+
+	output, err = cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine upstream branch: %s does not track a branch", current)
+	}
+
+	merge := strings.TrimSpace(string(output))
+	return strings.TrimPrefix(merge, "refs/heads/"), nil
+}
+
+// encodePushMessage encodes a patch set message for the m= push option. Gerrit replaces
+// underscores with spaces and then URL-decodes the value, so both are escaped here.
+func encodePushMessage(message string) string {
+	encoded := url.QueryEscape(message)
+	encoded = strings.ReplaceAll(encoded, "_", "%5F")
+	return strings.ReplaceAll(encoded, "+", "_")
+}
+
+// parsePushOutput extracts the changes Gerrit reports in the output of a push
+func parsePushOutput(output string) []PushedChange {
+	var changes []PushedChange
+	for _, match := range pushedChangePattern.FindAllStringSubmatch(output, -1) {
+		number, _ := strconv.Atoi(match[2])
+		patchSet, _ := strconv.Atoi(match[3])
+		subject := match[4]
+
+		change := PushedChange{URL: match[1], Number: number, PatchSet: patchSet}
+		if strings.HasSuffix(subject, "[NEW]") {
+			change.New = true
+			subject = strings.TrimSpace(strings.TrimSuffix(subject, "[NEW]"))
+			if change.PatchSet == 0 {
+				change.PatchSet = 1
+			}
+		}
+		change.Subject = subject
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
@@ -0,0 +1,154 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestCheckPush(t *testing.T) {
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "--quiet", "--allow-empty", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		remote  string
+		commit  string
+		branch  string
+		wantErr bool
+	}{
+		{name: "valid", remote: "origin", commit: "HEAD", branch: "main"},
+		{name: "default commit", remote: "origin", branch: "release/1.0"},
+		{name: "remote option", remote: "--receive-pack=touch pwned", commit: "HEAD", branch: "main", wantErr: true},
+		{name: "commit option", remote: "origin", commit: "--exec=touch pwned", branch: "main", wantErr: true},
+		{name: "branch option", remote: "origin", commit: "HEAD", branch: "-main", wantErr: true},
+		{name: "invalid branch", remote: "origin", commit: "HEAD", branch: "main..feature", wantErr: true},
+		{name: "unknown commit", remote: "origin", commit: "does-not-exist", branch: "main", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckPush(context.Background(), dir, tt.remote, tt.commit, tt.branch)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidArgument) {
+					t.Errorf("CheckPush() error = %v, want %v", err, ErrInvalidArgument)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("CheckPush() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestPushArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		opts PushOptions
+		want []string
+	}{
+		{
+			name: "no options",
+			want: []string{"push", "origin", "HEAD:refs/for/main"},
+		},
+		{
+			name: "options",
+			opts: PushOptions{Topic: "fix", Reviewers: []string{"jane"}, WIP: true},
+			want: []string{"push", "-o", "topic=fix", "-o", "r=jane", "-o", "wip", "origin", "HEAD:refs/for/main"},
+		},
+		{
+			name: "message",
+			opts: PushOptions{Message: "fix my_var + 100%"},
+			want: []string{"push", "-o", "m=fix_my%5Fvar_%2B_100%25", "origin", "HEAD:refs/for/main"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PushArgs("origin", "", "main", tt.opts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PushArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePushOutput(t *testing.T) {
+	output := `remote: Processing changes: refs: 2, new: 1, updated: 1, done
+remote:
+remote: SUCCESS
+remote:
+remote:   https://review.example.com/c/project/+/12345 Fix the thing
+remote:   https://review.example.com/c/project/+/12346 Add the other thing [NEW]
+remote:   https://review.example.com/c/project/+/12347/4 Amend a third thing
+remote:
+To ssh://review.example.com:29418/project
+ * [new reference]   HEAD -> refs/for/main
+`
+
+	want := []PushedChange{
+		{URL: "https://review.example.com/c/project/+/12345", Number: 12345, Subject: "Fix the thing"},
+		{URL: "https://review.example.com/c/project/+/12346", Number: 12346, PatchSet: 1, Subject: "Add the other thing", New: true},
+		{URL: "https://review.example.com/c/project/+/12347/4", Number: 12347, PatchSet: 4, Subject: "Amend a third thing"},
+	}
+
+	if got := parsePushOutput(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePushOutput() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGetUpstreamBranch(t *testing.T) {
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+
+	git("init", "--quiet", "--initial-branch=main")
+	git("-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "--quiet", "--allow-empty", "-m", "initial")
+
+	if _, err := GetUpstreamBranch(dir); err == nil {
+		t.Error("GetUpstreamBranch() without an upstream succeeded")
+	}
+
+	tests := []struct {
+		name   string
+		remote string
+		merge  string
+		want   string
+	}{
+		{name: "remote branch", remote: "origin", merge: "refs/heads/main", want: "main"},
+		{name: "remote branch with slash", remote: "origin", merge: "refs/heads/release/1.0", want: "release/1.0"},
+		{name: "local branch with slash", remote: ".", merge: "refs/heads/feature/x", want: "feature/x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			git("config", "branch.main.remote", tt.remote)
+			git("config", "branch.main.merge", tt.merge)
+
+			got, err := GetUpstreamBranch(dir)
+			if err != nil {
+				t.Fatalf("GetUpstreamBranch() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetUpstreamBranch() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
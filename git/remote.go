@@ -0,0 +1,256 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrNoRemote is returned when the repository has no remotes configured
+	ErrNoRemote = errors.New("no git remotes configured")
+
+	// ErrRemoteNotFound is returned when an explicitly requested remote does not exist
+	ErrRemoteNotFound = errors.New("git remote not found")
+)
+
+// scpLikePattern matches scp-like remote URLs such as git@host:project
+var scpLikePattern = regexp.MustCompile(`^(?:([^@/]+)@)?([^:/]+):(.*)$`)
+
+// Remote represents a git remote configured in a repository. ReviewURL and the structured fields
+// (Scheme through Path) describe the URL used to reach Gerrit.
+type Remote struct {
+	Name         string   `json:"name"`
+	URL          string   `json:"url"`
+	PushURL      string   `json:"pushUrl,omitempty"`
+	PushRefspecs []string `json:"pushRefspecs,omitempty"`
+	ReviewURL    string   `json:"reviewUrl,omitempty"`
+	Scheme       string   `json:"scheme,omitempty"`
+	User         string   `json:"user,omitempty"`
+	Host         string   `json:"host,omitempty"`
+	Port         int      `json:"port,omitempty"`
+	Path         string   `json:"path,omitempty"`
+}
+
+// ParseRemote parses a git remote URL into a Remote. It understands ssh://, http(s):// and
+// scp-like (user@host:project) URLs.
+func ParseRemote(rawURL string) (Remote, error) {
+	remote := Remote{URL: rawURL}
+
+	if strings.Contains(rawURL, "://") {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return Remote{}, fmt.Errorf("could not parse git remote URL %s: %w", rawURL, err)
+		}
+
+		remote.Scheme = u.Scheme
+		remote.Host = strings.ToLower(u.Hostname())
+		remote.Path = u.Path
+		if u.User != nil {
+			remote.User = u.User.Username()
+		}
+		if port := u.Port(); port != "" {
+			remote.Port, err = strconv.Atoi(port)
+			if err != nil {
+				return Remote{}, fmt.Errorf("invalid port in git remote URL %s: %w", rawURL, err)
+			}
+		}
+	} else if match := scpLikePattern.FindStringSubmatch(rawURL); match != nil {
+		remote.Scheme = "ssh"
+		remote.User = match[1]
+		remote.Host = strings.ToLower(match[2])
+		remote.Path = "/" + strings.TrimPrefix(match[3], "/")
+	}
+
+	if remote.Host == "" {
+		return Remote{}, fmt.Errorf("could not parse host from git remote URL: %s", rawURL)
+	}
+
+	return remote, nil
+}
+
+// WebURL returns the base URL of the Gerrit web UI and REST API for the remote. HTTP remotes
+// keep their scheme and port (configure a base URL for context paths); SSH remotes are assumed to be served over HTTPS
+// on the default port, as the SSH port (usually 29418) is not the web port.
+func (r Remote) WebURL() string {
+	switch r.Scheme {
+	case "http", "https":
+		host := r.Host
+		if r.Port != 0 {
+			host = net.JoinHostPort(r.Host, strconv.Itoa(r.Port))
+		}
+		return fmt.Sprintf("%s://%s", r.Scheme, host)
+	default:
+		return "https://" + r.Host
+	}
+}
+
+// GerritURL returns the URL used to talk to Gerrit: the one GetRemote matched, or else the push URL
+func (r Remote) GerritURL() string {
+	if r.ReviewURL != "" {
+		return r.ReviewURL
+	}
+	if r.PushURL != "" {
+		return r.PushURL
+	}
+	return r.URL
+}
+
+// PushesForReview reports whether the remote pushes to refs/for/*, as Gerrit remotes usually do
+func (r Remote) PushesForReview() bool {
+	for _, refspec := range r.PushRefspecs {
+		if strings.Contains(refspec, "refs/for/") {
+			return true
+		}
+	}
+	return false
+}
+
+// ListRemotes lists the remotes configured in the repository, in the order git reports them
+func ListRemotes(cwd string) ([]Remote, error) {
+	cmd := exec.Command("git", "config", "--get-regexp", `^remote\..*\.(url|pushurl|push)$`)
+	cmd.Dir = cwd
+
+	output, err := cmd.Output()
+	if err != nil {
+		// git config exits with 1 when nothing matches
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return nil, ErrNoRemote
+		}
+		return nil, fmt.Errorf("failed to list git remotes: %w", err)
+	}
+
+	var remotes []Remote
+	index := map[string]int{}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		key, value, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+
+		// Remote names may contain dots, so split on the first and last dot only
+		key = strings.TrimPrefix(key, "remote.")
+		dot := strings.LastIndex(key, ".")
+		if dot < 0 {
+			continue
+		}
+		name, field := key[:dot], key[dot+1:]
+
+		i, ok := index[name]
+		if !ok {
+			i = len(remotes)
+			index[name] = i
+			remotes = append(remotes, Remote{Name: name})
+		}
+
+		switch field {
+		case "url":
+			remotes[i].URL = value
+		case "pushurl":
+			remotes[i].PushURL = value
+		case "push":
+			remotes[i].PushRefspecs = append(remotes[i].PushRefspecs, value)
+		}
+	}
+
+	if len(remotes) == 0 {
+		return nil, ErrNoRemote
+	}
+
+	return remotes, nil
+}
+
+// FindGerritRemote picks the remote pointing at Gerrit. An explicit name always wins; otherwise
+// remotes whose URL or push URL matches one of hosts are preferred, then remotes pushing to
+// refs/for/*, then origin, then the first remote.
+func FindGerritRemote(cwd, name string, hosts []string) (Remote, error) {
+	remotes, err := ListRemotes(cwd)
+	if err != nil {
+		return Remote{}, err
+	}
+
+	if name != "" {
+		for _, remote := range remotes {
+			if remote.Name == name {
+				return remote, nil
+			}
+		}
+		return Remote{}, fmt.Errorf("%w: %s", ErrRemoteNotFound, name)
+	}
+
+	for _, remote := range remotes {
+		for _, u := range []string{remote.PushURL, remote.URL} {
+			if parsed, err := ParseRemote(u); err == nil && isKnownHost(hosts, parsed.Host) {
+				return remote, nil
+			}
+		}
+	}
+
+	for _, remote := range remotes {
+		if remote.PushesForReview() {
+			return remote, nil
+		}
+	}
+
+	for _, remote := range remotes {
+		if remote.Name == "origin" {
+			return remote, nil
+		}
+	}
+
+	return remotes[0], nil
+}
+
+// isKnownHost reports whether host is one of hosts, ignoring case as DNS does
+func isKnownHost(hosts []string, host string) bool {
+	return slices.ContainsFunc(hosts, func(known string) bool {
+		return strings.EqualFold(known, host)
+	})
+}
+
+// GetRemote picks the Gerrit remote with FindGerritRemote and parses the URL used to reach Gerrit
+func GetRemote(cwd, name string, hosts []string) (Remote, error) {
+	remote, err := FindGerritRemote(cwd, name, hosts)
+	if err != nil {
+		return Remote{}, err
+	}
+
+	// Prefer the push URL only if it matches a known host, as it may point at a mirror otherwise
+	gerritURL := remote.GerritURL()
+	for _, u := range []string{remote.PushURL, remote.URL} {
+		if parsed, err := ParseRemote(u); err == nil && isKnownHost(hosts, parsed.Host) {
+			gerritURL = u
+			break
+		}
+	}
+
+	parsed, err := ParseRemote(gerritURL)
+	if err != nil {
+		return Remote{}, err
+	}
+
+	parsed.Name = remote.Name
+	parsed.URL = remote.URL
+	parsed.PushURL = remote.PushURL
+	parsed.PushRefspecs = remote.PushRefspecs
+	parsed.ReviewURL = gerritURL
+
+	return parsed, nil
+}
+
+// GetHostFromRemote extracts the Gerrit host from the remote chosen by FindGerritRemote
+func GetHostFromRemote(cwd, name string, hosts []string) (string, error) {
+	remote, err := GetRemote(cwd, name, hosts)
+	if err != nil {
+		return "", err
+	}
+
+	return remote.Host, nil
+}
@@ -0,0 +1,100 @@
+package git
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestParseRemote(t *testing.T) {
+	tests := []struct {
+		url    string
+		scheme string
+		user   string
+		host   string
+		port   int
+		path   string
+		webURL string
+	}{
+		{"git@review.example.com:project.git", "ssh", "git", "review.example.com", 0, "/project.git", "https://review.example.com"},
+		{"review.example.com:team/project", "ssh", "", "review.example.com", 0, "/team/project", "https://review.example.com"},
+		{"ssh://jane@review.example.com:29418/team/project", "ssh", "jane", "review.example.com", 29418, "/team/project", "https://review.example.com"},
+		{"https://review.example.com/project", "https", "", "review.example.com", 0, "/project", "https://review.example.com"},
+		{"https://review.example.com/a/project.git", "https", "", "review.example.com", 0, "/a/project.git", "https://review.example.com"},
+		{"https://review.example.com/team/a/project", "https", "", "review.example.com", 0, "/team/a/project", "https://review.example.com"},
+		{"http://localhost:8080/a/project", "http", "", "localhost", 8080, "/a/project", "http://localhost:8080"},
+		{"https://Review.Example.com/project", "https", "", "review.example.com", 0, "/project", "https://review.example.com"},
+		{"https://corp.example.com/gerrit/a/project", "https", "", "corp.example.com", 0, "/gerrit/a/project", "https://corp.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			remote, err := ParseRemote(tt.url)
+			if err != nil {
+				t.Fatalf("ParseRemote(%q) returned error: %v", tt.url, err)
+			}
+
+			if remote.Scheme != tt.scheme || remote.User != tt.user || remote.Host != tt.host || remote.Port != tt.port {
+				t.Errorf("ParseRemote(%q) = %s://%s@%s:%d, want %s://%s@%s:%d",
+					tt.url, remote.Scheme, remote.User, remote.Host, remote.Port, tt.scheme, tt.user, tt.host, tt.port)
+			}
+			if remote.Path != tt.path {
+				t.Errorf("ParseRemote(%q).Path = %q, want %q", tt.url, remote.Path, tt.path)
+			}
+			if got := remote.WebURL(); got != tt.webURL {
+				t.Errorf("ParseRemote(%q).WebURL() = %q, want %q", tt.url, got, tt.webURL)
+			}
+		})
+	}
+}
+
+func TestParseRemoteInvalid(t *testing.T) {
+	for _, url := range []string{"", "/local/path", "https:///project"} {
+		if _, err := ParseRemote(url); err == nil {
+			t.Errorf("ParseRemote(%q) returned no error", url)
+		}
+	}
+}
+
+func TestGetRemote(t *testing.T) {
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"remote", "add", "origin", "https://github.com/example/project"},
+		{"config", "remote.origin.pushurl", "ssh://jane@Review.Example.com:29418/project"},
+		{"remote", "add", "upstream", "https://mirror.example.com/project"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		remote string
+		hosts  []string
+		want   string
+		host   string
+		url    string
+	}{
+		{name: "push URL matching a known host in another case", hosts: []string{"review.example.com"}, want: "origin", host: "review.example.com", url: "ssh://jane@Review.Example.com:29418/project"},
+		{name: "no known host falls back to origin", want: "origin", host: "review.example.com", url: "ssh://jane@Review.Example.com:29418/project"},
+		{name: "explicit remote", remote: "upstream", hosts: []string{"review.example.com"}, want: "upstream", host: "mirror.example.com", url: "https://mirror.example.com/project"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remote, err := GetRemote(dir, tt.remote, tt.hosts)
+			if err != nil {
+				t.Fatalf("GetRemote() error = %v", err)
+			}
+			if remote.Name != tt.want || remote.Host != tt.host {
+				t.Errorf("GetRemote() = %s (%s), want %s (%s)", remote.Name, remote.Host, tt.want, tt.host)
+			}
+			if got := remote.GerritURL(); got != tt.url {
+				t.Errorf("GerritURL() = %s, want %s", got, tt.url)
+			}
+		})
+	}
+}
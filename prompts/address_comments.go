@@ -0,0 +1,54 @@
+package prompts
+
+import (
+	"context"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// AddressCommentsPrompt is the prompt definition for address_comments
+var AddressCommentsPrompt = newPrompt("address_comments",
+	"Address the unresolved comments on my current change: fix the code and draft a reply to every thread")
+
+// addressCommentsInstructions tells the model how to work through the threads
+const addressCommentsInstructions = `Address the unresolved comment threads on this Gerrit change, listed below.
+
+For each thread:
+1. Use locate_comment to find where the commented code is in my working tree now.
+2. Make the requested change, or decide that it should not be made.
+3. Use draft_comment with inReplyTo set to the last comment of the thread to reply: say what you changed, or why you did not change anything. Leave the thread unresolved only if you need the reviewer's input.
+
+Do not publish the replies or push the changes; tell me when you are done so I can check your work first.
+`
+
+// HandleAddressComments handles the address_comments prompt
+func HandleAddressComments(cfg *config.Config) func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		changeID, err := inferChangeID(cfg, request)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := newClient(cfg, request)
+		if err != nil {
+			return nil, err
+		}
+
+		change, err := client.GetChange(ctx, changeID)
+		if err != nil {
+			return nil, err
+		}
+
+		threads, err := client.GetUnresolvedThreads(ctx, changeID)
+		if err != nil {
+			return nil, err
+		}
+
+		return newResult("Address the unresolved comments on "+change.Subject,
+			addressCommentsInstructions,
+			renderChange(client, change),
+			renderThreads("Unresolved comments", threads),
+		), nil
+	}
+}
@@ -0,0 +1,188 @@
+// Package prompts provides MCP prompts for common Gerrit review workflows
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/bajankristof/gerry/gerrit"
+	"github.com/bajankristof/gerry/session"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxDiffFiles is the number of files whose diff is included in a prompt
+	maxDiffFiles = 50
+	// maxDiffBytes is the size of the diff included in a prompt, about 25k tokens
+	maxDiffBytes = 100_000
+)
+
+// Inject registers all Gerrit MCP prompts with the server. In read-only mode only the prompts
+// that do not ask for changes in Gerrit are registered.
+func Inject(s *server.MCPServer, cfg *config.Config) {
+	s.AddPrompt(SummarizeDiscussionPrompt, HandleSummarizeDiscussion(cfg))
+
+	if cfg.ReadOnly {
+		return
+	}
+
+	s.AddPrompt(ReviewChangePrompt, HandleReviewChange(cfg))
+
+	// Addressing comments works on a local checkout, which a shared server does not have
+	if !cfg.SessionCredentials {
+		s.AddPrompt(AddressCommentsPrompt, HandleAddressComments(cfg))
+	}
+}
+
+// changeArguments are the arguments every prompt takes to find its change, as in the tools
+var changeArguments = []mcp.PromptOption{
+	mcp.WithArgument("changeId",
+		mcp.ArgumentDescription("The Gerrit Change-Id or change number (default: the Change-Id of the current git commit)"),
+	),
+	mcp.WithArgument("commit",
+		mcp.ArgumentDescription("The local git commit to take the Change-Id from when changeId is omitted (default: HEAD)"),
+	),
+	mcp.WithArgument("directory",
+		mcp.ArgumentDescription("The directory containing the git repository (used to determine Gerrit host)"),
+	),
+	mcp.WithArgument("remote",
+		mcp.ArgumentDescription("The git remote pointing at Gerrit (default: auto-detected)"),
+	),
+}
+
+// newPrompt creates a prompt that takes the change arguments
+func newPrompt(name, description string) mcp.Prompt {
+	return mcp.NewPrompt(name, append([]mcp.PromptOption{mcp.WithPromptDescription(description)}, changeArguments...)...)
+}
+
+// newClient creates a Gerrit client for the repository in the request's directory
+func newClient(cfg *config.Config, request mcp.GetPromptRequest) (*gerrit.Client, error) {
+	return session.NewClient(cfg, request.Header, request.Params.Arguments["directory"], request.Params.Arguments["remote"])
+}
+
+// inferChangeID returns the changeId argument, or the Change-Id of the requested local commit
+func inferChangeID(cfg *config.Config, request mcp.GetPromptRequest) (string, error) {
+	arguments := request.Params.Arguments
+	return session.ChangeID(cfg, arguments["changeId"], arguments["directory"], arguments["commit"])
+}
+
+// renderChange renders the metadata of a change as markdown
+func renderChange(client *gerrit.Client, change gerrit.Change) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "## Change %d: %s\n\n", change.Number, change.Subject)
+	fmt.Fprintf(&sb, "- URL: %s/c/%s/+/%d\n", client.BaseURL(), change.Project, change.Number)
+	fmt.Fprintf(&sb, "- Change-Id: %s\n", change.ChangeID)
+	fmt.Fprintf(&sb, "- Project: %s\n", change.Project)
+	fmt.Fprintf(&sb, "- Branch: %s\n", change.Branch)
+	if change.Topic != "" {
+		fmt.Fprintf(&sb, "- Topic: %s\n", change.Topic)
+	}
+	fmt.Fprintf(&sb, "- Status: %s\n", change.Status)
+	if change.Owner != nil {
+		fmt.Fprintf(&sb, "- Owner: %s\n", change.Owner.DisplayName())
+	}
+	if revision, ok := change.Revisions[change.CurrentRevision]; ok {
+		fmt.Fprintf(&sb, "- Current patch set: %d (%s)\n", revision.Number, change.CurrentRevision)
+	}
+
+	return sb.String()
+}
+
+// renderThreads renders comment threads as markdown, one section per thread
+func renderThreads(title string, threads []gerrit.Thread) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "## %s\n\n", title)
+	if len(threads) == 0 {
+		sb.WriteString("No comments found.\n")
+		return sb.String()
+	}
+
+	for _, thread := range threads {
+		state := "resolved"
+		if thread.Unresolved {
+			state = "unresolved"
+		}
+
+		location := thread.Path
+		if thread.Line > 0 {
+			location = fmt.Sprintf("%s:%d", thread.Path, thread.Line)
+		}
+		if thread.Side == "PARENT" {
+			location += " (parent side)"
+		}
+
+		fmt.Fprintf(&sb, "### %s - %s, patch set %d, thread %s\n\n", location, state, thread.PatchSet, thread.ID)
+		for _, comment := range thread.Comments {
+			fmt.Fprintf(&sb, "**%s** (comment %s):\n", comment.Author.DisplayName(), comment.ID)
+			for _, line := range strings.Split(strings.TrimSpace(comment.Message), "\n") {
+				fmt.Fprintf(&sb, "> %s\n", line)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// renderDiff renders the unified diffs of the files in the current patch set of a change. Once
+// maxDiffFiles files or maxDiffBytes bytes are rendered the remaining files are only listed.
+func renderDiff(ctx context.Context, client *gerrit.Client, changeID string) (string, error) {
+	files, err := client.ListFiles(ctx, changeID, "", 0)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Diff\n\n")
+
+	var omitted []string
+	for i, file := range files {
+		if len(omitted) > 0 || i >= maxDiffFiles {
+			omitted = append(omitted, file.Path)
+			continue
+		}
+
+		if file.Binary {
+			fmt.Fprintf(&sb, "Binary file %s changed.\n\n", file.Path)
+			continue
+		}
+
+		diff, err := client.GetDiff(ctx, changeID, "", file.Path, gerrit.DiffOptions{Context: 3})
+		if err != nil {
+			return "", err
+		}
+
+		unified := diff.Unified()
+		if sb.Len()+len(unified) > maxDiffBytes {
+			omitted = append(omitted, file.Path)
+			continue
+		}
+
+		fmt.Fprintf(&sb, "```diff\n%s```\n\n", unified)
+	}
+
+	if len(omitted) > 0 {
+		fmt.Fprintf(&sb, "The diff is too large to include in full. Use the get_diff tool to read the %d omitted files:\n\n", len(omitted))
+		for _, path := range omitted {
+			fmt.Fprintf(&sb, "- %s\n", path)
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// newResult creates a prompt result consisting of a single user message made of the given sections
+func newResult(description string, sections ...string) *mcp.GetPromptResult {
+	for i, section := range sections {
+		sections[i] = strings.TrimSpace(section)
+	}
+
+	return mcp.NewGetPromptResult(description, []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(strings.Join(sections, "\n\n"))),
+	})
+}
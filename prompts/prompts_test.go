@@ -0,0 +1,96 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bajankristof/gerry/gerrit"
+)
+
+func TestRenderThreads(t *testing.T) {
+	tests := []struct {
+		name    string
+		threads []gerrit.Thread
+		want    string
+	}{
+		{
+			name: "no threads",
+			want: "## Comments\n\nNo comments found.\n",
+		},
+		{
+			name: "threads",
+			threads: []gerrit.Thread{
+				{
+					ID: "c1", Path: "main.go", Line: 42, PatchSet: 2, Unresolved: true,
+					Comments: []gerrit.Comment{
+						{ID: "c1", Author: gerrit.Author{Name: "Jane"}, Message: "Rename this.\nIt is unclear."},
+						{ID: "c2", Author: gerrit.Author{Name: "John"}, Message: "Which name?"},
+					},
+				},
+				{
+					ID: "c3", Path: "main.go", Line: 7, Side: "PARENT", PatchSet: 1,
+					Comments: []gerrit.Comment{{ID: "c3", Author: gerrit.Author{Name: "Jane"}, Message: "Done"}},
+				},
+			},
+			want: `## Comments
+
+### main.go:42 - unresolved, patch set 2, thread c1
+
+**Jane** (comment c1):
+> Rename this.
+> It is unclear.
+
+**John** (comment c2):
+> Which name?
+
+### main.go:7 (parent side) - resolved, patch set 1, thread c3
+
+**Jane** (comment c3):
+> Done
+
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderThreads("Comments", tt.threads); got != tt.want {
+				t.Errorf("renderThreads() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderDiffOmitsFiles(t *testing.T) {
+	files := maxDiffFiles + 2
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/files") {
+			entries := make([]string, files)
+			for i := range entries {
+				entries[i] = fmt.Sprintf("%q: {}", fmt.Sprintf("file%02d.go", i))
+			}
+			fmt.Fprintf(w, ")]}'\n{%s}", strings.Join(entries, ","))
+			return
+		}
+		w.Write([]byte(`)]}'
+{"meta_a": {"name": "f.go"}, "meta_b": {"name": "f.go"}, "change_type": "MODIFIED", "content": [{"a": ["old"], "b": ["new"]}]}`))
+	}))
+	defer server.Close()
+
+	client := gerrit.NewClientWithBaseURL(server.URL, "", "")
+	got, err := renderDiff(context.Background(), client, "123")
+	if err != nil {
+		t.Fatalf("renderDiff() error = %v", err)
+	}
+
+	if count := strings.Count(got, "```diff"); count != maxDiffFiles {
+		t.Errorf("renderDiff() rendered %d diffs, want %d", count, maxDiffFiles)
+	}
+	if !strings.Contains(got, "read the 2 omitted files") || !strings.Contains(got, "- file50.go\n- file51.go\n") {
+		t.Errorf("renderDiff() does not list the omitted files:\n%s", got)
+	}
+}
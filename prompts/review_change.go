@@ -0,0 +1,57 @@
+package prompts
+
+import (
+	"context"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ReviewChangePrompt is the prompt definition for review_change
+var ReviewChangePrompt = newPrompt("review_change",
+	"Review a change for correctness and draft comments on the problems found")
+
+// reviewChangeInstructions tells the model what to look for and how to report it
+const reviewChangeInstructions = `Review this Gerrit change for correctness. Its metadata, the existing discussion and the diff of the current patch set are below.
+
+Look for bugs, unhandled errors and edge cases, races, security problems, and behaviour that does not match the commit message. Skip anything that is already discussed in an existing thread, and do not comment on style unless it hides a bug.
+
+Use draft_comment for each problem, anchored to the line it is about, and mark it unresolved if it must be fixed before the change can be merged. Then give me a short summary with the vote you would cast. Do not publish the review; I will do that after reading your comments.
+`
+
+// HandleReviewChange handles the review_change prompt
+func HandleReviewChange(cfg *config.Config) func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		changeID, err := inferChangeID(cfg, request)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := newClient(cfg, request)
+		if err != nil {
+			return nil, err
+		}
+
+		change, err := client.GetChange(ctx, changeID)
+		if err != nil {
+			return nil, err
+		}
+
+		threads, err := client.GetThreads(ctx, changeID)
+		if err != nil {
+			return nil, err
+		}
+
+		diff, err := renderDiff(ctx, client, changeID)
+		if err != nil {
+			return nil, err
+		}
+
+		return newResult("Review "+change.Subject,
+			reviewChangeInstructions,
+			renderChange(client, change),
+			renderThreads("Existing comments", threads),
+			diff,
+		), nil
+	}
+}
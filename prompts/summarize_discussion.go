@@ -0,0 +1,49 @@
+package prompts
+
+import (
+	"context"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// SummarizeDiscussionPrompt is the prompt definition for summarize_discussion
+var SummarizeDiscussionPrompt = newPrompt("summarize_discussion",
+	"Summarize the review discussion on a change: what was decided, what is still open and who is waiting on whom")
+
+// summarizeDiscussionInstructions tells the model what the summary should cover
+const summarizeDiscussionInstructions = `Summarize the review discussion on this Gerrit change, using the comment threads below.
+
+Cover what was agreed and changed, which threads are still unresolved and what each of them needs, and who the change is waiting on. Keep it short; group related threads instead of going through them one by one.
+`
+
+// HandleSummarizeDiscussion handles the summarize_discussion prompt
+func HandleSummarizeDiscussion(cfg *config.Config) func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		changeID, err := inferChangeID(cfg, request)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := newClient(cfg, request)
+		if err != nil {
+			return nil, err
+		}
+
+		change, err := client.GetChange(ctx, changeID)
+		if err != nil {
+			return nil, err
+		}
+
+		threads, err := client.GetThreads(ctx, changeID)
+		if err != nil {
+			return nil, err
+		}
+
+		return newResult("Summarize the discussion on "+change.Subject,
+			summarizeDiscussionInstructions,
+			renderChange(client, change),
+			renderThreads("Comments", threads),
+		), nil
+	}
+}
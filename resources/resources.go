@@ -0,0 +1,204 @@
+// Package resources exposes Gerrit changes as MCP resources
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/bajankristof/gerry/gerrit"
+	"github.com/bajankristof/gerry/session"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+var (
+	// ErrInvalidURI is returned for resource URIs that do not match any of the gerrit:// templates
+	ErrInvalidURI = errors.New("invalid resource URI")
+
+	// ErrUnknownHost is returned for resource URIs naming a host that is not a configured Gerrit host
+	ErrUnknownHost = errors.New("unknown Gerrit host. Only the gerritHost and the hosts configured in ~/.config/gerry.json can be read as resources")
+)
+
+// ChangeTemplate is the resource template for a change
+var ChangeTemplate = mcp.NewResourceTemplate("gerrit://{host}/changes/{id}", "change",
+	mcp.WithTemplateDescription("A Gerrit change with its owner, status and patch sets, by change number or Change-Id"),
+	mcp.WithTemplateMIMEType("application/json"),
+)
+
+// CommentsTemplate is the resource template for the comment threads of a change
+var CommentsTemplate = mcp.NewResourceTemplate("gerrit://{host}/changes/{id}/comments", "comments",
+	mcp.WithTemplateDescription("The comment threads of a Gerrit change, with their resolution state"),
+	mcp.WithTemplateMIMEType("application/json"),
+)
+
+// DiffTemplate is the resource template for the diff of a file in a change
+var DiffTemplate = mcp.NewResourceTemplate("gerrit://{host}/changes/{id}/files/{+path}/diff", "diff",
+	mcp.WithTemplateDescription("The unified diff of a file in the current patch set of a Gerrit change"),
+	mcp.WithTemplateMIMEType("text/x-diff"),
+)
+
+// Inject registers the Gerrit MCP resource templates with the server
+func Inject(s *server.MCPServer, cfg *config.Config) {
+	s.AddResourceTemplate(ChangeTemplate, HandleChange(cfg))
+	s.AddResourceTemplate(CommentsTemplate, HandleComments(cfg))
+	s.AddResourceTemplate(DiffTemplate, HandleDiff(cfg))
+}
+
+// HandleChange handles reads of change resources
+func HandleChange(cfg *config.Config) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		uri, err := ParseURI(request.Params.URI)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := newClient(cfg, uri, request.Header)
+		if err != nil {
+			return nil, err
+		}
+
+		change, err := client.GetChange(ctx, uri.ChangeID)
+		if err != nil {
+			return nil, err
+		}
+
+		return jsonContents(request.Params.URI, change)
+	}
+}
+
+// HandleComments handles reads of comment resources
+func HandleComments(cfg *config.Config) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		uri, err := ParseURI(request.Params.URI)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := newClient(cfg, uri, request.Header)
+		if err != nil {
+			return nil, err
+		}
+
+		threads, err := client.GetThreads(ctx, uri.ChangeID)
+		if err != nil {
+			return nil, err
+		}
+
+		if threads == nil {
+			threads = []gerrit.Thread{}
+		}
+
+		return jsonContents(request.Params.URI, threads)
+	}
+}
+
+// HandleDiff handles reads of diff resources
+func HandleDiff(cfg *config.Config) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		uri, err := ParseURI(request.Params.URI)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := newClient(cfg, uri, request.Header)
+		if err != nil {
+			return nil, err
+		}
+
+		diff, err := client.GetDiff(ctx, uri.ChangeID, "", uri.Path, gerrit.DiffOptions{Context: 3})
+		if err != nil {
+			return nil, err
+		}
+
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "text/x-diff",
+				Text:     diff.Unified(),
+			},
+		}, nil
+	}
+}
+
+// URI represents a parsed gerrit:// resource URI
+type URI struct {
+	Host     string
+	ChangeID string
+	// Kind is "change", "comments" or "diff"
+	Kind string
+	// Path is the file path of a diff resource
+	Path string
+}
+
+// ParseURI parses a gerrit:// resource URI
+func ParseURI(raw string) (URI, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "gerrit" || u.Host == "" {
+		return URI{}, fmt.Errorf("%w: %s", ErrInvalidURI, raw)
+	}
+
+	rest, ok := strings.CutPrefix(u.Path, "/changes/")
+	if !ok {
+		return URI{}, fmt.Errorf("%w: %s", ErrInvalidURI, raw)
+	}
+
+	changeID, rest, _ := strings.Cut(rest, "/")
+	if changeID == "" {
+		return URI{}, fmt.Errorf("%w: %s", ErrInvalidURI, raw)
+	}
+
+	uri := URI{Host: u.Host, ChangeID: changeID}
+	switch {
+	case rest == "":
+		uri.Kind = "change"
+	case rest == "comments":
+		uri.Kind = "comments"
+	case strings.HasPrefix(rest, "files/") && strings.HasSuffix(rest, "/diff"):
+		uri.Kind = "diff"
+		uri.Path = strings.TrimSuffix(strings.TrimPrefix(rest, "files/"), "/diff")
+	}
+
+	if uri.Kind == "" || (uri.Kind == "diff" && uri.Path == "") {
+		return URI{}, fmt.Errorf("%w: %s", ErrInvalidURI, raw)
+	}
+
+	return uri, nil
+}
+
+// Hostname returns the host of the URI without its port
+func (u URI) Hostname() string {
+	return (&url.URL{Host: u.Host}).Hostname()
+}
+
+// newClient creates a Gerrit client for the host of a resource URI using its configured settings and
+// the credentials of the request with the given HTTP headers. Hosts that are not configured are
+// rejected, so a resource URI cannot send credentials or requests to an arbitrary host.
+func newClient(cfg *config.Config, uri URI, header http.Header) (*gerrit.Client, error) {
+	if !cfg.IsKnownHost(uri.Hostname()) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownHost, uri.Host)
+	}
+
+	return session.NewClientForHost(cfg, header, uri.Host)
+}
+
+// jsonContents renders v as the JSON contents of the resource at uri
+func jsonContents(uri string, v any) ([]mcp.ResourceContents, error) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "application/json",
+			Text:     string(b),
+		},
+	}, nil
+}
@@ -0,0 +1,269 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultPollInterval is how often the changes behind subscribed resources are checked for updates
+const DefaultPollInterval = time.Minute
+
+// ErrNoSession is returned when a subscription arrives outside of a client session, so there is
+// no client to notify
+var ErrNoSession = errors.New("no client session to notify")
+
+// Watcher tracks the resource subscriptions of each client session and notifies the session when
+// a subscribed change gets a new patch set or new comments
+type Watcher struct {
+	cfg      *config.Config
+	interval time.Duration
+
+	mu      sync.Mutex
+	changes map[changeKey]*watchedChange
+}
+
+// changeKey identifies a change on a Gerrit host watched for a client session
+type changeKey struct {
+	session  string
+	host     string
+	changeID string
+}
+
+// watchedChange represents a change with subscribed resources and its last seen state
+type watchedChange struct {
+	uris map[string]URI
+	// header holds the HTTP headers of the subscribe request, which carry session credentials
+	header   http.Header
+	revision string
+	comments string
+}
+
+// resourceUpdate represents a notification that a subscribed resource changed
+type resourceUpdate struct {
+	session string
+	uri     string
+}
+
+// NewWatcher creates a Watcher that polls subscribed changes every interval
+func NewWatcher(cfg *config.Config, interval time.Duration) *Watcher {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	return &Watcher{
+		cfg:      cfg,
+		interval: interval,
+		changes:  make(map[changeKey]*watchedChange),
+	}
+}
+
+// Hooks returns the server hooks that hand resources/subscribe and resources/unsubscribe requests,
+// and the end of client sessions, to the watcher. Pass them to the server with server.WithHooks
+// along with server.WithResourceCapabilities(true, ...).
+func (w *Watcher) Hooks() *server.Hooks {
+	hooks := &server.Hooks{}
+
+	hooks.AddAfterSubscribe(func(ctx context.Context, _ any, request *mcp.SubscribeRequest, _ *mcp.EmptyResult) {
+		if err := w.Subscribe(ctx, sessionID(ctx), request.Params.URI, request.Header); err != nil {
+			slog.Warn("failed to subscribe to resource", "uri", request.Params.URI, "error", err)
+		}
+	})
+	hooks.AddAfterUnsubscribe(func(ctx context.Context, _ any, request *mcp.UnsubscribeRequest, _ *mcp.EmptyResult) {
+		w.Unsubscribe(sessionID(ctx), request.Params.URI)
+	})
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		w.forget(session.SessionID())
+	})
+
+	return hooks
+}
+
+// Subscribe starts watching the change behind a resource URI for a client session. The current
+// state of the change is recorded right away, so updates made before the next poll are not missed.
+func (w *Watcher) Subscribe(ctx context.Context, session, raw string, header http.Header) error {
+	if session == "" {
+		return ErrNoSession
+	}
+
+	uri, err := ParseURI(raw)
+	if err != nil {
+		return err
+	}
+
+	key := changeKey{session: session, host: uri.Host, changeID: uri.ChangeID}
+
+	w.mu.Lock()
+	change, ok := w.changes[key]
+	if ok {
+		change.uris[raw] = uri
+	}
+	w.mu.Unlock()
+
+	if ok {
+		return nil
+	}
+
+	revision, comments, err := w.state(ctx, key, header)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	change, ok = w.changes[key]
+	if !ok {
+		change = &watchedChange{uris: make(map[string]URI), header: header, revision: revision, comments: comments}
+		w.changes[key] = change
+	}
+	change.uris[raw] = uri
+
+	return nil
+}
+
+// Unsubscribe stops watching a resource URI for a client session
+func (w *Watcher) Unsubscribe(session, raw string) {
+	uri, err := ParseURI(raw)
+	if err != nil {
+		return
+	}
+
+	key := changeKey{session: session, host: uri.Host, changeID: uri.ChangeID}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if change, ok := w.changes[key]; ok {
+		delete(change.uris, raw)
+		if len(change.uris) == 0 {
+			delete(w.changes, key)
+		}
+	}
+}
+
+// Run polls the subscribed changes until ctx is cancelled and notifies the sessions of s whose
+// resources changed
+func (w *Watcher) Run(ctx context.Context, s *server.MCPServer) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.notify(s, w.poll(ctx))
+		}
+	}
+}
+
+// notify sends a resource update notification for each update to its session
+func (w *Watcher) notify(s *server.MCPServer, updates []resourceUpdate) {
+	for _, update := range updates {
+		err := s.SendNotificationToSpecificClient(update.session, mcp.MethodNotificationResourceUpdated, map[string]any{"uri": update.uri})
+		if errors.Is(err, server.ErrSessionNotFound) {
+			w.forget(update.session)
+		} else if err != nil {
+			slog.Warn("failed to notify resource update", "uri", update.uri, "error", err)
+		}
+	}
+}
+
+// poll fetches the state of every watched change and returns the subscribed resources that changed
+func (w *Watcher) poll(ctx context.Context) []resourceUpdate {
+	w.mu.Lock()
+	keys := make(map[changeKey]http.Header, len(w.changes))
+	for key, change := range w.changes {
+		keys[key] = change.header
+	}
+	w.mu.Unlock()
+
+	var updates []resourceUpdate
+	for key, header := range keys {
+		revision, comments, err := w.state(ctx, key, header)
+		if err != nil {
+			slog.Warn("failed to watch change", "host", key.host, "change", key.changeID, "error", err)
+			continue
+		}
+
+		w.mu.Lock()
+		watched, ok := w.changes[key]
+		if !ok {
+			w.mu.Unlock()
+			continue
+		}
+
+		newPatchSet := watched.revision != revision
+		newComments := watched.comments != comments
+		watched.revision, watched.comments = revision, comments
+
+		for raw, uri := range watched.uris {
+			switch {
+			case uri.Kind == "change" && (newPatchSet || newComments),
+				uri.Kind == "comments" && newComments,
+				uri.Kind == "diff" && newPatchSet:
+				updates = append(updates, resourceUpdate{session: key.session, uri: raw})
+			}
+		}
+		w.mu.Unlock()
+	}
+
+	return updates
+}
+
+// state fetches the current revision of a change and a fingerprint of its comments
+func (w *Watcher) state(ctx context.Context, key changeKey, header http.Header) (string, string, error) {
+	client, err := newClient(w.cfg, URI{Host: key.host}, header)
+	if err != nil {
+		return "", "", err
+	}
+
+	change, err := client.GetChange(ctx, key.changeID)
+	if err != nil {
+		return "", "", err
+	}
+
+	comments, err := client.GetComments(ctx, key.changeID)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Published comments are never deleted, so their count and latest update identify the discussion
+	latest := ""
+	for _, comment := range comments {
+		if comment.Updated > latest {
+			latest = comment.Updated
+		}
+	}
+
+	return change.CurrentRevision, fmt.Sprintf("%d/%s", len(comments), latest), nil
+}
+
+// forget drops all subscriptions of a client session
+func (w *Watcher) forget(session string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for key := range w.changes {
+		if key.session == session {
+			delete(w.changes, key)
+		}
+	}
+}
+
+// sessionID returns the ID of the client session of a request, or "" outside of a session
+func sessionID(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+	return ""
+}
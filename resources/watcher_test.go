@@ -0,0 +1,137 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// testSession is a client session that collects the notifications sent to it
+type testSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *testSession) Initialize()       {}
+func (s *testSession) Initialized() bool { return true }
+func (s *testSession) SessionID() string { return "test" }
+func (s *testSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+func TestWatcher(t *testing.T) {
+	var mu sync.Mutex
+	revision, comments := "rev1", `{}`
+	gerrit := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/changes/123"):
+			fmt.Fprintf(w, ")]}'\n{\"id\": \"123\", \"current_revision\": %q}", revision)
+		case strings.HasSuffix(r.URL.Path, "/changes/123/comments"):
+			fmt.Fprintf(w, ")]}'\n%s", comments)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer gerrit.Close()
+
+	cfg := &config.Config{
+		Hosts: map[string]config.HostConfig{
+			"review.example.com": {BaseURL: gerrit.URL, Username: "jane", Password: "secret"},
+		},
+	}
+
+	watcher := NewWatcher(cfg, time.Hour)
+	s := server.NewMCPServer("test", "0.0.0",
+		server.WithResourceCapabilities(true, false),
+		server.WithHooks(watcher.Hooks()),
+	)
+
+	session := &testSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	if err := s.RegisterSession(context.Background(), session); err != nil {
+		t.Fatalf("RegisterSession() error = %v", err)
+	}
+	ctx := s.WithContext(context.Background(), session)
+
+	send := func(method, uri string) {
+		t.Helper()
+		message := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": %q, "params": {"uri": %q}}`, method, uri)
+		if response, ok := s.HandleMessage(ctx, []byte(message)).(mcp.JSONRPCResponse); !ok {
+			t.Fatalf("%s %s = %+v, want a result", method, uri, response)
+		}
+	}
+
+	updated := func() []string {
+		t.Helper()
+		watcher.notify(s, watcher.poll(ctx))
+
+		var uris []string
+		for {
+			select {
+			case notification := <-session.notifications:
+				if notification.Method != mcp.MethodNotificationResourceUpdated {
+					t.Errorf("notification method = %s, want %s", notification.Method, mcp.MethodNotificationResourceUpdated)
+				}
+				uris = append(uris, fmt.Sprint(notification.Params.AdditionalFields["uri"]))
+			default:
+				return uris
+			}
+		}
+	}
+
+	diff := "gerrit://review.example.com/changes/123/files/main.go/diff"
+	thread := "gerrit://review.example.com/changes/123/comments"
+	send("resources/subscribe", diff)
+	send("resources/subscribe", thread)
+
+	if uris := updated(); len(uris) != 0 {
+		t.Errorf("updates without changes = %v, want none", uris)
+	}
+
+	mu.Lock()
+	revision = "rev2"
+	mu.Unlock()
+
+	if uris := updated(); len(uris) != 1 || uris[0] != diff {
+		t.Errorf("updates after a new patch set = %v, want [%s]", uris, diff)
+	}
+
+	mu.Lock()
+	comments = `{"main.go": [{"id": "c1", "updated": "2024-01-01 10:00:00.000000000"}]}`
+	mu.Unlock()
+
+	if uris := updated(); len(uris) != 1 || uris[0] != thread {
+		t.Errorf("updates after a new comment = %v, want [%s]", uris, thread)
+	}
+
+	send("resources/unsubscribe", diff)
+	mu.Lock()
+	revision = "rev3"
+	mu.Unlock()
+
+	if uris := updated(); len(uris) != 0 {
+		t.Errorf("updates after unsubscribing = %v, want none", uris)
+	}
+}
+
+func TestWatcherUnknownHost(t *testing.T) {
+	watcher := NewWatcher(&config.Config{GerritHost: "review.example.com"}, time.Hour)
+
+	err := watcher.Subscribe(context.Background(), "test", "gerrit://attacker.example.com/changes/123", nil)
+	if err == nil {
+		t.Fatal("Subscribe() to an unknown host succeeded")
+	}
+	if len(watcher.changes) != 0 {
+		t.Errorf("watched changes = %d, want 0", len(watcher.changes))
+	}
+}
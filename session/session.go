@@ -0,0 +1,112 @@
+// Package session creates the Gerrit clients that tool, prompt and resource requests act with
+package session
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/bajankristof/gerry/gerrit"
+	"github.com/bajankristof/gerry/git"
+)
+
+var (
+	// ErrChangeIDRequired is returned when a shared server is asked to detect a Change-Id from git
+	ErrChangeIDRequired = errors.New("changeId is required: a shared server cannot detect it from a local git repository")
+
+	// ErrNoSharedHost is returned when a shared server has no gerritHost to send requests to
+	ErrNoSharedHost = errors.New("no Gerrit host configured. A shared server needs gerritHost in ~/.config/gerry.json, as it never reads a local git repository")
+)
+
+// NewClient creates a Gerrit client for the repository in directory, using the configuration of the
+// Gerrit host its remote points at and the credentials of the request with the given HTTP headers.
+// A shared server never reads a local repository and always uses the configured gerritHost.
+func NewClient(cfg *config.Config, header http.Header, directory, remote string) (*gerrit.Client, error) {
+	if cfg.SessionCredentials {
+		if cfg.GerritHost == "" {
+			return nil, ErrNoSharedHost
+		}
+		return NewClientForHost(cfg, header, cfg.GerritHost)
+	}
+
+	if remote == "" {
+		remote = cfg.Remote
+	}
+
+	connection := cfg.SessionConnection(header)
+	opts := gerrit.GitOptions{
+		Remote: remote,
+		Hosts:  cfg.KnownHosts(),
+		HostOptions: func(remote git.Remote) (gerrit.HostOptions, error) {
+			conn, err := connection(config.CredentialRequest{
+				Host:       remote.Host,
+				BaseURL:    remote.WebURL(),
+				Directory:  directory,
+				FromRemote: true,
+			})
+			if err != nil {
+				return gerrit.HostOptions{}, err
+			}
+
+			return hostOptions(conn), nil
+		},
+	}
+
+	return gerrit.NewClientFromGit(directory, opts)
+}
+
+// NewClientForHost creates a Gerrit client for a host, optionally with a port, using the settings
+// configured for its hostname and the credentials of the request with the given HTTP headers
+func NewClientForHost(cfg *config.Config, header http.Header, host string) (*gerrit.Client, error) {
+	hostname := (&url.URL{Host: host}).Hostname()
+	conn, err := cfg.SessionConnection(header)(config.CredentialRequest{Host: hostname})
+	if err != nil {
+		return nil, err
+	}
+
+	return gerrit.NewClientForHost(host, hostOptions(conn)), nil
+}
+
+// ChangeID returns changeID if given, otherwise the Change-Id of commit (default: HEAD) in the
+// repository in directory. A shared server never runs git for a session, so there it is required.
+func ChangeID(cfg *config.Config, changeID, directory, commit string) (string, error) {
+	if changeID != "" {
+		return changeID, nil
+	}
+
+	if cfg.SessionCredentials {
+		return "", ErrChangeIDRequired
+	}
+
+	if commit == "" {
+		commit = "HEAD"
+	}
+
+	changeID, err := git.GetChangeIDFromRevision(directory, commit)
+	if err != nil {
+		return "", fmt.Errorf("could not auto-detect changeId from git: %w", err)
+	}
+
+	if changeID == "" {
+		if commit == "HEAD" {
+			return "", fmt.Errorf("no Change-Id found in current commit (use install_commit_msg_hook with amend to add one)")
+		}
+		return "", fmt.Errorf("no Change-Id found in commit %s", commit)
+	}
+
+	return changeID, nil
+}
+
+// hostOptions converts a resolved connection into the options of a Gerrit client
+func hostOptions(conn config.Connection) gerrit.HostOptions {
+	return gerrit.HostOptions{
+		BaseURL:  conn.BaseURL,
+		Username: conn.Credentials.Username,
+		Password: conn.Credentials.Password,
+		Cookie:   conn.Credentials.Cookie,
+		Timeout:  conn.Timeout,
+		DryRun:   conn.DryRun,
+	}
+}
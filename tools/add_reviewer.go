@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/bajankristof/gerry/gerrit"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// AddReviewerTool is the tool definition for add_reviewer
+var AddReviewerTool = mcp.NewTool("add_reviewer",
+	mcp.WithDescription("Add a reviewer or CC to a Gerrit change. The reviewer can be an account (username, email or account ID) or a group name. Use suggest_reviewers to find suitable reviewers."),
+	mcp.WithReadOnlyHintAnnotation(false),
+	mcp.WithDestructiveHintAnnotation(false),
+	mcp.WithOutputSchema[gerrit.AddReviewerResult](),
+	mcp.WithString("changeId",
+		mcp.Description("The Gerrit Change-Id (e.g., I1234567890abcdef...). Optional - if not provided, automatically uses the Change-Id from the current git commit."),
+	),
+	mcp.WithString("commit",
+		mcp.Description("The local git commit to take the Change-Id from when changeId is omitted, for working on changes deeper in a stack (e.g., HEAD~2 or a commit SHA; default: HEAD)"),
+	),
+	mcp.WithString("reviewer",
+		mcp.Required(),
+		mcp.Description("The account (username, email or account ID) or group to add"),
+	),
+	mcp.WithString("state",
+		mcp.Description("Whether to add the account as a reviewer or as a CC (default: REVIEWER)"),
+		mcp.Enum("REVIEWER", "CC"),
+	),
+	mcp.WithString("directory",
+		mcp.Description("The directory containing the git repository (used to determine Gerrit host)"),
+	),
+	mcp.WithString("remote",
+		mcp.Description("The git remote pointing at Gerrit (default: auto-detected from the configured Gerrit host or a remote pushing to refs/for/*)"),
+	),
+)
+
+// HandleAddReviewer handles the add_reviewer tool call
+func HandleAddReviewer(cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		changeID, err := inferChangeID(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		reviewer, err := request.RequireString("reviewer")
+		if err != nil {
+			return mcp.NewToolResultError("reviewer is required"), nil
+		}
+
+		client, err := newClient(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		input := gerrit.AddReviewerInput{
+			Reviewer: reviewer,
+			State:    request.GetString("state", ""),
+		}
+
+		result, err := client.AddReviewer(ctx, changeID, input)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		return mcp.NewToolResultStructured(result, renderAddReviewer(result)), nil
+	}
+}
@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/bajankristof/gerry/git"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CheckoutChangeTool is the tool definition for checkout_change
+var CheckoutChangeTool = mcp.NewTool("checkout_change",
+	mcp.WithDescription("Fetch a Gerrit change (or a specific patch set of it) and check it out locally into a new branch, or into a separate git worktree to keep the current checkout untouched. Use this to run tests on a colleague's change."),
+	mcp.WithReadOnlyHintAnnotation(false),
+	mcp.WithDestructiveHintAnnotation(false),
+	mcp.WithString("changeId",
+		mcp.Required(),
+		mcp.Description("The change number or Gerrit Change-Id to check out"),
+	),
+	mcp.WithNumber("patchSet",
+		mcp.Description("The patch set number to check out (default: the current patch set)"),
+	),
+	mcp.WithString("branch",
+		mcp.Description("The name of the local branch to create (default: change-<number>-ps<patchSet>)"),
+	),
+	mcp.WithString("worktree",
+		mcp.Description("Check out into a new git worktree at this path (relative to the directory) instead of switching the current checkout"),
+	),
+	mcp.WithString("directory",
+		mcp.Description("The directory containing the git repository"),
+	),
+	mcp.WithString("remote",
+		mcp.Description("The git remote pointing at Gerrit (default: auto-detected from the configured Gerrit host or a remote pushing to refs/for/*)"),
+	),
+)
+
+// HandleCheckoutChange handles the checkout_change tool call
+func HandleCheckoutChange(cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		changeID, err := request.RequireString("changeId")
+		if err != nil {
+			return mcp.NewToolResultError("changeId is required"), nil
+		}
+
+		directory := request.GetString("directory", "")
+		if directory == "" {
+			directory = "."
+		}
+
+		client, err := newClient(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		change, err := client.GetChange(ctx, changeID)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		commit, ref, err := change.Revision(request.GetInt("patchSet", 0))
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		remote, err := git.GetRemote(directory, request.GetString("remote", cfg.Remote), cfg.KnownHosts())
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		patchSet := change.Revisions[commit].Number
+		branch := request.GetString("branch", fmt.Sprintf("change-%d-ps%d", change.Number, patchSet))
+
+		worktree := request.GetString("worktree", "")
+		if worktree != "" && !filepath.IsAbs(worktree) {
+			worktree = filepath.Join(directory, worktree)
+		}
+
+		if cfg.DryRun {
+			if err := git.CheckCheckout(ctx, worktree, branch, commit); err != nil {
+				return toolError(ctx, err), nil
+			}
+
+			checkout := []string{"checkout", "-b", branch, commit}
+			if worktree != "" {
+				checkout = []string{"worktree", "add", "-b", branch, worktree, commit}
+			}
+			return mcp.NewToolResultText("Dry run - the following commands were not run:\n\n" +
+				commandLine([]string{"fetch", remote.GerritURL(), ref}) + "\n" + commandLine(checkout)), nil
+		}
+
+		if err := git.Fetch(ctx, directory, remote.GerritURL(), ref); err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		if worktree != "" {
+			if err := git.AddWorktree(ctx, directory, worktree, branch, commit); err != nil {
+				return toolError(ctx, err), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Checked out change %d patch set %d (%s) into branch %s in worktree %s.", change.Number, patchSet, commit, branch, worktree)), nil
+		}
+
+		if err := git.CheckoutBranch(ctx, directory, branch, commit); err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Checked out change %d patch set %d (%s) into branch %s.", change.Number, patchSet, commit, branch)), nil
+	}
+}
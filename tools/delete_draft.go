@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// DeleteDraftTool is the tool definition for delete_draft
+var DeleteDraftTool = mcp.NewTool("delete_draft",
+	mcp.WithDescription("Delete one of your draft comments on a Gerrit change. Use list_drafts to find draft IDs."),
+	mcp.WithReadOnlyHintAnnotation(false),
+	mcp.WithDestructiveHintAnnotation(true),
+	mcp.WithString("changeId",
+		mcp.Description("The Gerrit Change-Id (e.g., I1234567890abcdef...). Optional - if not provided, automatically uses the Change-Id from the current git commit."),
+	),
+	mcp.WithString("commit",
+		mcp.Description("The local git commit to take the Change-Id from when changeId is omitted, for working on changes deeper in a stack (e.g., HEAD~2 or a commit SHA; default: HEAD)"),
+	),
+	mcp.WithString("draftId",
+		mcp.Required(),
+		mcp.Description("The ID of the draft to delete"),
+	),
+	mcp.WithString("directory",
+		mcp.Description("The directory containing the git repository (used to determine Gerrit host)"),
+	),
+	mcp.WithString("remote",
+		mcp.Description("The git remote pointing at Gerrit (default: auto-detected from the configured Gerrit host or a remote pushing to refs/for/*)"),
+	),
+)
+
+// HandleDeleteDraft handles the delete_draft tool call
+func HandleDeleteDraft(cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		changeID, err := inferChangeID(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		draftID, err := request.RequireString("draftId")
+		if err != nil {
+			return mcp.NewToolResultError("draftId is required"), nil
+		}
+
+		client, err := newClient(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		if err := client.DeleteDraft(ctx, changeID, draftID); err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		return mcp.NewToolResultText("Success."), nil
+	}
+}
@@ -11,10 +11,15 @@ import (
 
 // DraftCommentTool is the tool definition for draft_comment
 var DraftCommentTool = mcp.NewTool("draft_comment",
-	mcp.WithDescription("Create a draft comment or reply on a Gerrit change. Drafts are not visible until published with publish_review."),
+	mcp.WithDescription("Create a draft comment or reply on a Gerrit change. Comments can be anchored to a line or to an exact character range, on a specific patch set, and on the parent side for comments on deleted lines. Replies are placed on the patch set of the comment they reply to. Drafts are not visible until published with publish_review."),
+	mcp.WithReadOnlyHintAnnotation(false),
+	mcp.WithDestructiveHintAnnotation(false),
 	mcp.WithString("changeId",
 		mcp.Description("The Gerrit Change-Id (e.g., I1234567890abcdef...). Optional - if not provided, automatically uses the Change-Id from the current git commit."),
 	),
+	mcp.WithString("commit",
+		mcp.Description("The local git commit to take the Change-Id from when changeId is omitted, for working on changes deeper in a stack (e.g., HEAD~2 or a commit SHA; default: HEAD)"),
+	),
 	mcp.WithString("message",
 		mcp.Required(),
 		mcp.Description("The comment or reply message"),
@@ -26,6 +31,25 @@ var DraftCommentTool = mcp.NewTool("draft_comment",
 	mcp.WithNumber("line",
 		mcp.Description("The line number for the comment (omit for file-level comments)"),
 	),
+	mcp.WithNumber("startLine",
+		mcp.Description("The start line of the commented range (use with startCharacter, endLine and endCharacter instead of line)"),
+	),
+	mcp.WithNumber("startCharacter",
+		mcp.Description("The character offset within startLine where the commented range starts (0-based)"),
+	),
+	mcp.WithNumber("endLine",
+		mcp.Description("The end line of the commented range"),
+	),
+	mcp.WithNumber("endCharacter",
+		mcp.Description("The character offset within endLine where the commented range ends (exclusive)"),
+	),
+	mcp.WithString("revision",
+		mcp.Description("The patch set number or commit SHA to comment on (default: the patch set of the comment being replied to, otherwise current)"),
+	),
+	mcp.WithString("side",
+		mcp.Description("The side of the diff to comment on; use PARENT to comment on deleted lines (default: REVISION, or the side of the comment replied to)"),
+		mcp.Enum("REVISION", "PARENT"),
+	),
 	mcp.WithString("inReplyTo",
 		mcp.Description("The comment ID to reply to (omit for new comments)"),
 	),
@@ -35,14 +59,17 @@ var DraftCommentTool = mcp.NewTool("draft_comment",
 	mcp.WithString("directory",
 		mcp.Description("The directory containing the git repository (used to determine Gerrit host)"),
 	),
+	mcp.WithString("remote",
+		mcp.Description("The git remote pointing at Gerrit (default: auto-detected from the configured Gerrit host or a remote pushing to refs/for/*)"),
+	),
 )
 
 // HandleDraftComment handles the draft_comment tool call
 func HandleDraftComment(cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		changeID, err := inferChangeID(request)
+		changeID, err := inferChangeID(cfg, request)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error: %v", err)), nil
+			return toolError(ctx, err), nil
 		}
 
 		message, err := request.RequireString("message")
@@ -55,24 +82,65 @@ func HandleDraftComment(cfg *config.Config) func(ctx context.Context, request mc
 			return mcp.NewToolResultError("path is required"), nil
 		}
 
-		directory := request.GetString("directory", "")
-		client, err := gerrit.NewClientFromGit(directory, cfg.GerritUsername, cfg.GerritPassword)
+		client, err := newClient(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		commentRange, err := getRange(request)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error: %v", err)), nil
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
 		input := gerrit.DraftCommentInput{
 			Message:    message,
 			Path:       path,
 			Line:       request.GetInt("line", 0),
+			Range:      commentRange,
 			InReplyTo:  request.GetString("inReplyTo", ""),
 			Unresolved: request.GetBool("unresolved", false),
 		}
 
-		if err := client.DraftComment(changeID, input); err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error: %v", err)), nil
+		// Replies inherit the side of their parent unless a side is given
+		input.Side = request.GetString("side", "")
+
+		if err := client.DraftComment(ctx, changeID, request.GetString("revision", ""), input); err != nil {
+			return toolError(ctx, err), nil
 		}
 
 		return mcp.NewToolResultText("Success."), nil
 	}
 }
+
+// getRange extracts the commented character range from the request, if any
+func getRange(request mcp.CallToolRequest) (*gerrit.Range, error) {
+	keys := []string{"startLine", "startCharacter", "endLine", "endCharacter"}
+
+	values := make([]int, len(keys))
+	present := 0
+	for i, key := range keys {
+		if value, err := request.RequireInt(key); err == nil {
+			values[i] = value
+			present++
+		}
+	}
+
+	if present == 0 {
+		return nil, nil
+	}
+
+	if present != len(keys) {
+		return nil, fmt.Errorf("startLine, startCharacter, endLine and endCharacter must be provided together")
+	}
+
+	if values[0] > values[2] || (values[0] == values[2] && values[1] > values[3]) {
+		return nil, fmt.Errorf("the range must not end before it starts")
+	}
+
+	return &gerrit.Range{
+		StartLine:      values[0],
+		StartCharacter: values[1],
+		EndLine:        values[2],
+		EndCharacter:   values[3],
+	}, nil
+}
@@ -2,8 +2,6 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 
 	"github.com/bajankristof/gerry/config"
 	"github.com/bajankristof/gerry/gerrit"
@@ -13,38 +11,40 @@ import (
 // GetChangeTool is the tool definition for get_change
 var GetChangeTool = mcp.NewTool("get_change",
 	mcp.WithDescription("Get information about a Gerrit change by its Change-Id. Returns details like project, branch, subject, status, and current revision."),
+	mcp.WithReadOnlyHintAnnotation(true),
+	mcp.WithOutputSchema[gerrit.Change](),
 	mcp.WithString("changeId",
 		mcp.Description("The Gerrit Change-Id (e.g., I1234567890abcdef...). Optional - if not provided, automatically uses the Change-Id from the current git commit."),
 	),
+	mcp.WithString("commit",
+		mcp.Description("The local git commit to take the Change-Id from when changeId is omitted, for working on changes deeper in a stack (e.g., HEAD~2 or a commit SHA; default: HEAD)"),
+	),
 	mcp.WithString("directory",
 		mcp.Description("The directory containing the git repository (used to determine Gerrit host)"),
 	),
+	mcp.WithString("remote",
+		mcp.Description("The git remote pointing at Gerrit (default: auto-detected from the configured Gerrit host or a remote pushing to refs/for/*)"),
+	),
 )
 
 // HandleGetChange handles the get_change tool call
 func HandleGetChange(cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		changeID, err := inferChangeID(request)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error: %v", err)), nil
-		}
-
-		directory := request.GetString("directory", "")
-		client, err := gerrit.NewClientFromGit(directory, cfg.GerritUsername, cfg.GerritPassword)
+		changeID, err := inferChangeID(cfg, request)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error: %v", err)), nil
+			return toolError(ctx, err), nil
 		}
 
-		change, err := client.GetChange(changeID)
+		client, err := newClient(cfg, request)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error: %v", err)), nil
+			return toolError(ctx, err), nil
 		}
 
-		changeJSON, err := json.MarshalIndent(change, "", "  ")
+		change, err := client.GetChange(ctx, changeID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error: %v", err)), nil
+			return toolError(ctx, err), nil
 		}
 
-		return mcp.NewToolResultText(string(changeJSON)), nil
+		return mcp.NewToolResultStructured(change, renderChange(change)), nil
 	}
 }
@@ -11,6 +11,10 @@ import (
 // GetChangeIDTool is the tool definition for get_change_id
 var GetChangeIDTool = mcp.NewTool("get_change_id",
 	mcp.WithDescription("Get the Gerrit Change-Id from the current git commit. Note: Other Gerrit tools automatically detect the Change-Id from the current commit, so you typically don't need to call this tool first. Use this only if you need to explicitly retrieve or display the Change-Id."),
+	mcp.WithReadOnlyHintAnnotation(true),
+	mcp.WithString("commit",
+		mcp.Description("The git commit to read the Change-Id from (e.g., HEAD~2 or a commit SHA; default: HEAD)"),
+	),
 	mcp.WithString("directory",
 		mcp.Description("The directory containing the git repository"),
 	),
@@ -20,13 +24,15 @@ var GetChangeIDTool = mcp.NewTool("get_change_id",
 func HandleGetChangeID(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	directory := request.GetString("directory", "")
 
-	changeID, err := git.GetChangeIDFromCommit(directory)
+	commit := request.GetString("commit", "HEAD")
+
+	changeID, err := git.GetChangeIDFromRevision(directory, commit)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error: %v", err)), nil
+		return toolError(ctx, err), nil
 	}
 
 	if changeID == "" {
-		return mcp.NewToolResultText("No Change-Id found in the current commit. Make sure you're in a git repository with a Gerrit commit."), nil
+		return mcp.NewToolResultText(fmt.Sprintf("No Change-Id found in commit %s. Make sure you're in a git repository with a Gerrit commit, or use install_commit_msg_hook to add one.", commit)), nil
 	}
 
 	return mcp.NewToolResultText(changeID), nil
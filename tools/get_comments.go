@@ -2,53 +2,53 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 
 	"github.com/bajankristof/gerry/config"
-	"github.com/bajankristof/gerry/gerrit"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
 // GetCommentsTool is the tool definition for get_comments
 var GetCommentsTool = mcp.NewTool("get_comments",
-	mcp.WithDescription("Get all comments for a Gerrit change. Returns a list of all comments (both resolved and unresolved) with their file path, line number, message, author, and resolution status."),
+	mcp.WithDescription("Get all comment threads for a Gerrit change, grouped by file and sorted by line and update time. Each thread contains the root comment and its replies (with file path, line number, message and author), and is unresolved if its last comment is unresolved."),
+	mcp.WithReadOnlyHintAnnotation(true),
+	mcp.WithOutputSchema[threadList](),
 	mcp.WithString("changeId",
 		mcp.Description("The Gerrit Change-Id (e.g., I1234567890abcdef...). Optional - if not provided, automatically uses the Change-Id from the current git commit."),
 	),
+	mcp.WithString("commit",
+		mcp.Description("The local git commit to take the Change-Id from when changeId is omitted, for working on changes deeper in a stack (e.g., HEAD~2 or a commit SHA; default: HEAD)"),
+	),
 	mcp.WithString("directory",
 		mcp.Description("The directory containing the git repository (used to determine Gerrit host)"),
 	),
+	mcp.WithString("remote",
+		mcp.Description("The git remote pointing at Gerrit (default: auto-detected from the configured Gerrit host or a remote pushing to refs/for/*)"),
+	),
 )
 
 // HandleGetComments handles the get_comments tool call
 func HandleGetComments(cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		changeID, err := inferChangeID(request)
+		changeID, err := inferChangeID(cfg, request)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error: %v", err)), nil
+			return toolError(ctx, err), nil
 		}
 
-		directory := request.GetString("directory", "")
-		client, err := gerrit.NewClientFromGit(directory, cfg.GerritUsername, cfg.GerritPassword)
+		client, err := newClient(cfg, request)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error: %v", err)), nil
+			return toolError(ctx, err), nil
 		}
 
-		comments, err := client.GetComments(changeID)
+		threads, err := client.GetThreads(ctx, changeID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error: %v", err)), nil
+			return toolError(ctx, err), nil
 		}
 
-		if len(comments) == 0 {
-			return mcp.NewToolResultText("No comments found."), nil
-		}
-
-		commentsJSON, err := json.MarshalIndent(comments, "", "  ")
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error: %v", err)), nil
+		result := threadList{Files: groupThreadsByFile(threads)}
+		if len(threads) == 0 {
+			return mcp.NewToolResultStructured(result, "No comments found."), nil
 		}
 
-		return mcp.NewToolResultText(string(commentsJSON)), nil
+		return mcp.NewToolResultStructured(result, renderThreads(result.Files)), nil
 	}
 }
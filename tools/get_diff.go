@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/bajankristof/gerry/gerrit"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetDiffTool is the tool definition for get_diff
+var GetDiffTool = mcp.NewTool("get_diff",
+	mcp.WithDescription("Get the diff of a single file in a Gerrit change as a unified diff. By default the current patch set is compared against its parent; use revision and base to compare two patch sets (e.g., base 3 and revision 5)."),
+	mcp.WithReadOnlyHintAnnotation(true),
+	mcp.WithString("changeId",
+		mcp.Description("The Gerrit Change-Id (e.g., I1234567890abcdef...). Optional - if not provided, automatically uses the Change-Id from the current git commit."),
+	),
+	mcp.WithString("commit",
+		mcp.Description("The local git commit to take the Change-Id from when changeId is omitted, for working on changes deeper in a stack (e.g., HEAD~2 or a commit SHA; default: HEAD)"),
+	),
+	mcp.WithString("path",
+		mcp.Required(),
+		mcp.Description("The file path to get the diff for"),
+	),
+	mcp.WithString("revision",
+		mcp.Description("The revision to get the diff for: a patch set number, commit SHA or 'current' (default: current)"),
+	),
+	mcp.WithNumber("base",
+		mcp.Description("The patch set number to compare against (omit to compare against the parent commit)"),
+	),
+	mcp.WithNumber("context",
+		mcp.Description("The number of context lines around each change (default: 3, 0 returns the whole file)"),
+	),
+	mcp.WithString("whitespace",
+		mcp.Description("How whitespace changes are treated (default: IGNORE_NONE)"),
+		mcp.Enum("IGNORE_NONE", "IGNORE_TRAILING", "IGNORE_LEADING_AND_TRAILING", "IGNORE_ALL"),
+	),
+	mcp.WithString("directory",
+		mcp.Description("The directory containing the git repository (used to determine Gerrit host)"),
+	),
+	mcp.WithString("remote",
+		mcp.Description("The git remote pointing at Gerrit (default: auto-detected from the configured Gerrit host or a remote pushing to refs/for/*)"),
+	),
+)
+
+// HandleGetDiff handles the get_diff tool call
+func HandleGetDiff(cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		changeID, err := inferChangeID(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		path, err := request.RequireString("path")
+		if err != nil {
+			return mcp.NewToolResultError("path is required"), nil
+		}
+
+		client, err := newClient(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		opts := gerrit.DiffOptions{
+			Base:       request.GetInt("base", 0),
+			Context:    request.GetInt("context", 3),
+			Whitespace: request.GetString("whitespace", ""),
+		}
+
+		diff, err := client.GetDiff(ctx, changeID, request.GetString("revision", ""), path, opts)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		return mcp.NewToolResultText(diff.Unified()), nil
+	}
+}
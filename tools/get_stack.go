@@ -0,0 +1,37 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/bajankristof/gerry/git"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetStackTool is the tool definition for get_stack
+var GetStackTool = mcp.NewTool("get_stack",
+	mcp.WithDescription("List the local commits in the current stack of changes (from the merge-base with the upstream branch up to HEAD), newest first, with their commit SHA, subject and Change-Id. Pass a commit from this list as the commit argument of other tools to work on a change deeper in the stack."),
+	mcp.WithReadOnlyHintAnnotation(true),
+	mcp.WithOutputSchema[stack](),
+	mcp.WithString("base",
+		mcp.Description("The revision the stack starts from, exclusive (default: the merge-base with the upstream branch)"),
+	),
+	mcp.WithString("directory",
+		mcp.Description("The directory containing the git repository"),
+	),
+)
+
+// HandleGetStack handles the get_stack tool call
+func HandleGetStack(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	directory := request.GetString("directory", "")
+
+	commits, err := git.GetStack(directory, request.GetString("base", ""))
+	if err != nil {
+		return toolError(ctx, err), nil
+	}
+
+	if len(commits) == 0 {
+		return mcp.NewToolResultStructured(stack{Commits: []git.StackCommit{}}, "No commits found in the current stack."), nil
+	}
+
+	return mcp.NewToolResultStructured(stack{Commits: commits}, renderStack(commits)), nil
+}
@@ -0,0 +1,109 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/bajankristof/gerry/git"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// InstallCommitMsgHookTool is the tool definition for install_commit_msg_hook
+var InstallCommitMsgHookTool = mcp.NewTool("install_commit_msg_hook",
+	mcp.WithDescription("Install Gerrit's commit-msg hook, which adds a Change-Id to every commit message, by downloading it from the Gerrit host. Honours core.hooksPath and linked worktrees. Optionally amends the message of HEAD so it gets a Change-Id too (staged changes stay staged; refused while a merge or rebase is in progress). Use this when a repository has no Change-Id in its commits."),
+	mcp.WithReadOnlyHintAnnotation(false),
+	mcp.WithDestructiveHintAnnotation(false),
+	mcp.WithBoolean("amend",
+		mcp.Description("Amend HEAD so it gets a Change-Id if it has none (default: false)"),
+	),
+	mcp.WithBoolean("force",
+		mcp.Description("Replace an existing commit-msg hook (default: false)"),
+	),
+	mcp.WithString("directory",
+		mcp.Description("The directory containing the git repository (used to determine Gerrit host)"),
+	),
+	mcp.WithString("remote",
+		mcp.Description("The git remote pointing at Gerrit (default: auto-detected from the configured Gerrit host or a remote pushing to refs/for/*)"),
+	),
+)
+
+// HandleInstallCommitMsgHook handles the install_commit_msg_hook tool call
+func HandleInstallCommitMsgHook(cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		directory := request.GetString("directory", "")
+		if directory == "" {
+			directory = "."
+		}
+
+		installed, err := git.HasHook(directory, "commit-msg")
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		var result string
+		if installed && !request.GetBool("force", false) {
+			path, err := git.GetHookPath(directory, "commit-msg")
+			if err != nil {
+				return toolError(ctx, err), nil
+			}
+
+			result = fmt.Sprintf("A commit-msg hook is already installed at %s.", path)
+		} else {
+			client, err := newClient(cfg, request)
+			if err != nil {
+				return toolError(ctx, err), nil
+			}
+
+			if cfg.DryRun {
+				path, err := git.GetHookPath(directory, "commit-msg")
+				if err != nil {
+					return toolError(ctx, err), nil
+				}
+
+				result = fmt.Sprintf("Dry run - would install the commit-msg hook from %s/tools/hooks/commit-msg at %s.", client.BaseURL(), path)
+			} else {
+				hook, err := client.GetCommitMsgHook(ctx)
+				if err != nil {
+					return toolError(ctx, err), nil
+				}
+
+				path, err := git.InstallHook(directory, "commit-msg", hook)
+				if err != nil {
+					return toolError(ctx, err), nil
+				}
+
+				result = fmt.Sprintf("Installed the commit-msg hook from %s at %s.", client.Host(), path)
+			}
+		}
+
+		if request.GetBool("amend", false) {
+			if cfg.DryRun {
+				changeID, err := git.GetChangeIDFromCommit(directory)
+				if err != nil {
+					return toolError(ctx, err), nil
+				}
+
+				if changeID != "" {
+					result += fmt.Sprintf(" HEAD already has Change-Id %s.", changeID)
+				} else {
+					if err := git.CheckAmend(directory); err != nil {
+						return toolError(ctx, err), nil
+					}
+					result += " Dry run - would amend HEAD to add a Change-Id with: " + commandLine(git.AmendArgs())
+				}
+
+				return mcp.NewToolResultText(result), nil
+			}
+
+			changeID, err := git.AmendChangeID(directory)
+			if err != nil {
+				return toolError(ctx, err), nil
+			}
+
+			result += fmt.Sprintf(" HEAD has Change-Id %s.", changeID)
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/bajankristof/gerry/gerrit"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ListDraftsTool is the tool definition for list_drafts
+var ListDraftsTool = mcp.NewTool("list_drafts",
+	mcp.WithDescription("List your draft comments on a Gerrit change across all patch sets. Drafts are not visible to others until published with publish_review, so use this to review pending comments before publishing."),
+	mcp.WithReadOnlyHintAnnotation(true),
+	mcp.WithOutputSchema[commentList](),
+	mcp.WithString("changeId",
+		mcp.Description("The Gerrit Change-Id (e.g., I1234567890abcdef...). Optional - if not provided, automatically uses the Change-Id from the current git commit."),
+	),
+	mcp.WithString("commit",
+		mcp.Description("The local git commit to take the Change-Id from when changeId is omitted, for working on changes deeper in a stack (e.g., HEAD~2 or a commit SHA; default: HEAD)"),
+	),
+	mcp.WithString("directory",
+		mcp.Description("The directory containing the git repository (used to determine Gerrit host)"),
+	),
+	mcp.WithString("remote",
+		mcp.Description("The git remote pointing at Gerrit (default: auto-detected from the configured Gerrit host or a remote pushing to refs/for/*)"),
+	),
+)
+
+// HandleListDrafts handles the list_drafts tool call
+func HandleListDrafts(cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		changeID, err := inferChangeID(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		client, err := newClient(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		drafts, err := client.ListDrafts(ctx, changeID)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		if len(drafts) == 0 {
+			return mcp.NewToolResultStructured(commentList{Comments: []gerrit.Comment{}}, "No drafts found."), nil
+		}
+
+		return mcp.NewToolResultStructured(commentList{Comments: drafts}, renderComments(drafts)), nil
+	}
+}
@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ListFilesTool is the tool definition for list_files
+var ListFilesTool = mcp.NewTool("list_files",
+	mcp.WithDescription("List the files modified in a Gerrit change. Returns each file's path, status (A=added, M=modified, D=deleted, R=renamed, C=copied, W=rewritten), lines inserted and deleted, size delta and old path for renames. The commit message and merge list Gerrit shows as files are not included. Use this to plan a review before fetching individual diffs with get_diff."),
+	mcp.WithReadOnlyHintAnnotation(true),
+	mcp.WithOutputSchema[fileList](),
+	mcp.WithString("changeId",
+		mcp.Description("The Gerrit Change-Id (e.g., I1234567890abcdef...). Optional - if not provided, automatically uses the Change-Id from the current git commit."),
+	),
+	mcp.WithString("commit",
+		mcp.Description("The local git commit to take the Change-Id from when changeId is omitted, for working on changes deeper in a stack (e.g., HEAD~2 or a commit SHA; default: HEAD)"),
+	),
+	mcp.WithString("revision",
+		mcp.Description("The revision to list files for: a patch set number, commit SHA or 'current' (default: current)"),
+	),
+	mcp.WithNumber("base",
+		mcp.Description("The patch set number to compare against (omit to compare against the parent commit)"),
+	),
+	mcp.WithString("directory",
+		mcp.Description("The directory containing the git repository (used to determine Gerrit host)"),
+	),
+	mcp.WithString("remote",
+		mcp.Description("The git remote pointing at Gerrit (default: auto-detected from the configured Gerrit host or a remote pushing to refs/for/*)"),
+	),
+)
+
+// HandleListFiles handles the list_files tool call
+func HandleListFiles(cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		changeID, err := inferChangeID(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		client, err := newClient(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		files, err := client.ListFiles(ctx, changeID, request.GetString("revision", ""), request.GetInt("base", 0))
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		return mcp.NewToolResultStructured(fileList{Files: files}, renderFiles(files)), nil
+	}
+}
@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/bajankristof/gerry/gerrit"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ListReviewersTool is the tool definition for list_reviewers
+var ListReviewersTool = mcp.NewTool("list_reviewers",
+	mcp.WithDescription("List the reviewers and CCs of a Gerrit change. Returns each account with the votes they have cast."),
+	mcp.WithReadOnlyHintAnnotation(true),
+	mcp.WithOutputSchema[reviewerList](),
+	mcp.WithString("changeId",
+		mcp.Description("The Gerrit Change-Id (e.g., I1234567890abcdef...). Optional - if not provided, automatically uses the Change-Id from the current git commit."),
+	),
+	mcp.WithString("commit",
+		mcp.Description("The local git commit to take the Change-Id from when changeId is omitted, for working on changes deeper in a stack (e.g., HEAD~2 or a commit SHA; default: HEAD)"),
+	),
+	mcp.WithString("directory",
+		mcp.Description("The directory containing the git repository (used to determine Gerrit host)"),
+	),
+	mcp.WithString("remote",
+		mcp.Description("The git remote pointing at Gerrit (default: auto-detected from the configured Gerrit host or a remote pushing to refs/for/*)"),
+	),
+)
+
+// HandleListReviewers handles the list_reviewers tool call
+func HandleListReviewers(cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		changeID, err := inferChangeID(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		client, err := newClient(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		reviewers, err := client.ListReviewers(ctx, changeID)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		if len(reviewers) == 0 {
+			return mcp.NewToolResultStructured(reviewerList{Reviewers: []gerrit.Reviewer{}}, "No reviewers found."), nil
+		}
+
+		return mcp.NewToolResultStructured(reviewerList{Reviewers: reviewers}, renderReviewers(reviewers)), nil
+	}
+}
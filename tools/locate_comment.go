@@ -0,0 +1,195 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/bajankristof/gerry/gerrit"
+	"github.com/bajankristof/gerry/git"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// LocateCommentTool is the tool definition for locate_comment
+var LocateCommentTool = mcp.NewTool("locate_comment",
+	mcp.WithDescription("Find where a review comment thread applies in the local working tree. Compares the commented patch set's version of the file with the working tree file and maps the comment's line (and range) to the current line number, reporting whether it is unchanged, moved or deleted. Use this before fixing feedback in a locally modified file."),
+	mcp.WithReadOnlyHintAnnotation(true),
+	mcp.WithOutputSchema[commentLocation](),
+	mcp.WithString("changeId",
+		mcp.Description("The Gerrit Change-Id (e.g., I1234567890abcdef...). Optional - if not provided, automatically uses the Change-Id from the current git commit."),
+	),
+	mcp.WithString("commit",
+		mcp.Description("The local git commit to take the Change-Id from when changeId is omitted, for working on changes deeper in a stack (e.g., HEAD~2 or a commit SHA; default: HEAD)"),
+	),
+	mcp.WithString("threadId",
+		mcp.Required(),
+		mcp.Description("The ID of the thread (its root comment) or of any comment in it"),
+	),
+	mcp.WithString("directory",
+		mcp.Description("The directory containing the git repository (used to determine Gerrit host and the working tree)"),
+	),
+	mcp.WithString("remote",
+		mcp.Description("The git remote pointing at Gerrit (default: auto-detected from the configured Gerrit host or a remote pushing to refs/for/*)"),
+	),
+)
+
+// commentLocation represents where a comment thread applies in the working tree
+type commentLocation struct {
+	ThreadID     string         `json:"threadId"`
+	Path         string         `json:"path"`
+	PatchSet     int            `json:"patchSet"`
+	Side         string         `json:"side,omitempty"`
+	Line         int            `json:"line"`
+	CurrentLine  int            `json:"currentLine"`
+	Status       git.LineStatus `json:"status"`
+	Range        *gerrit.Range  `json:"range,omitempty"`
+	CurrentRange *gerrit.Range  `json:"currentRange,omitempty"`
+	Message      string         `json:"message"`
+}
+
+// HandleLocateComment handles the locate_comment tool call
+func HandleLocateComment(cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		changeID, err := inferChangeID(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		threadID, err := request.RequireString("threadId")
+		if err != nil {
+			return mcp.NewToolResultError("threadId is required"), nil
+		}
+
+		client, err := newClient(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		threads, err := client.GetThreads(ctx, changeID)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		thread, ok := findThread(threads, threadID)
+		if !ok {
+			return toolError(ctx, fmt.Errorf("%w: %s", gerrit.ErrCommentNotFound, threadID)), nil
+		}
+
+		if _, ok := workingTreePath("", thread.Path); !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("Thread %s is not on a file in the working tree (%s)", threadID, thread.Path)), nil
+		}
+
+		location := commentLocation{
+			ThreadID: thread.ID,
+			Path:     thread.Path,
+			PatchSet: thread.PatchSet,
+			Side:     thread.Side,
+			Line:     thread.Line,
+			Range:    thread.Range,
+			Message:  thread.Comments[0].Message,
+		}
+
+		reviewed, err := client.GetFileContent(ctx, changeID, strconv.Itoa(thread.PatchSet), thread.Path, thread.Side == "PARENT")
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		directory := request.GetString("directory", "")
+		if directory == "" {
+			directory = "."
+		}
+
+		root, err := git.GetRoot(directory)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		path, _ := workingTreePath(root, thread.Path)
+		local, err := os.ReadFile(path)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				return toolError(ctx, err), nil
+			}
+			location.Status = git.LineDeleted
+			return locationResult(location), nil
+		}
+
+		if thread.Line == 0 {
+			// File-level comments only depend on the file still existing
+			location.Status = git.LineUnchanged
+			return locationResult(location), nil
+		}
+
+		hunks, err := git.DiffLines(reviewed, local)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		location.CurrentLine, location.Status = git.MapLine(hunks, thread.Line)
+
+		if thread.Range != nil {
+			startLine, startStatus := git.MapLine(hunks, thread.Range.StartLine)
+			endLine, endStatus := git.MapLine(hunks, thread.Range.EndLine)
+			if startStatus != git.LineDeleted && endStatus != git.LineDeleted {
+				location.CurrentRange = &gerrit.Range{
+					StartLine:      startLine,
+					StartCharacter: thread.Range.StartCharacter,
+					EndLine:        endLine,
+					EndCharacter:   thread.Range.EndCharacter,
+				}
+			} else {
+				location.Status = git.LineDeleted
+			}
+		}
+
+		return locationResult(location), nil
+	}
+}
+
+// workingTreePath returns the path of a file of a change in the working tree at root. Paths that
+// would leave root, and Gerrit's magic files such as /COMMIT_MSG, are not in the working tree.
+func workingTreePath(root, path string) (string, bool) {
+	local := filepath.FromSlash(path)
+	if !filepath.IsLocal(local) {
+		return "", false
+	}
+	return filepath.Join(root, local), true
+}
+
+// findThread finds the thread containing the comment with the given ID
+func findThread(threads []gerrit.Thread, commentID string) (gerrit.Thread, bool) {
+	for _, thread := range threads {
+		for _, comment := range thread.Comments {
+			if comment.ID == commentID {
+				return thread, true
+			}
+		}
+	}
+	return gerrit.Thread{}, false
+}
+
+// locationResult renders a comment location as a tool result
+func locationResult(location commentLocation) *mcp.CallToolResult {
+	where := location.Path
+	if location.Line > 0 {
+		where = fmt.Sprintf("%s:%d", location.Path, location.Line)
+	}
+
+	text := fmt.Sprintf("Thread %s on %s (patch set %d) ", location.ThreadID, where, location.PatchSet)
+	switch location.Status {
+	case git.LineDeleted:
+		text += "was changed or removed locally"
+		if location.CurrentLine > 0 {
+			text += fmt.Sprintf(", the region it was in now starts at line %d", location.CurrentLine)
+		}
+	case git.LineMoved:
+		text += fmt.Sprintf("is now at line %d", location.CurrentLine)
+	default:
+		text += "is still in place"
+	}
+
+	return mcp.NewToolResultStructured(location, text+":\n"+indent(location.Message, ""))
+}
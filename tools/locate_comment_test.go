@@ -0,0 +1,33 @@
+package tools
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestWorkingTreePath(t *testing.T) {
+	root := filepath.FromSlash("/repo")
+
+	tests := []struct {
+		path   string
+		want   string
+		wantOK bool
+	}{
+		{"src/main.go", filepath.FromSlash("/repo/src/main.go"), true},
+		{"src/../main.go", filepath.FromSlash("/repo/main.go"), true},
+		{"/COMMIT_MSG", "", false},
+		{"../outside.go", "", false},
+		{"src/../../outside.go", "", false},
+		{"/etc/passwd", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := workingTreePath(root, tt.path)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("workingTreePath(%q) = %q, %v, want %q, %v", tt.path, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/bajankristof/gerry/gerrit"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// MoveChangeTool is the tool definition for move_change
+var MoveChangeTool = mcp.NewTool("move_change",
+	mcp.WithDescription("Move a Gerrit change to another branch. Returns the updated change."),
+	mcp.WithReadOnlyHintAnnotation(false),
+	mcp.WithDestructiveHintAnnotation(false),
+	mcp.WithOutputSchema[gerrit.Change](),
+	mcp.WithString("changeId",
+		mcp.Description("The Gerrit Change-Id (e.g., I1234567890abcdef...). Optional - if not provided, automatically uses the Change-Id from the current git commit."),
+	),
+	mcp.WithString("commit",
+		mcp.Description("The local git commit to take the Change-Id from when changeId is omitted, for working on changes deeper in a stack (e.g., HEAD~2 or a commit SHA; default: HEAD)"),
+	),
+	mcp.WithString("branch",
+		mcp.Required(),
+		mcp.Description("The destination branch"),
+	),
+	mcp.WithString("message",
+		mcp.Description("Optional message explaining the action"),
+	),
+	mcp.WithString("directory",
+		mcp.Description("The directory containing the git repository (used to determine Gerrit host)"),
+	),
+	mcp.WithString("remote",
+		mcp.Description("The git remote pointing at Gerrit (default: auto-detected from the configured Gerrit host or a remote pushing to refs/for/*)"),
+	),
+)
+
+// HandleMoveChange handles the move_change tool call
+func HandleMoveChange(cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		changeID, err := inferChangeID(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		branch, err := request.RequireString("branch")
+		if err != nil {
+			return mcp.NewToolResultError("branch is required"), nil
+		}
+
+		client, err := newClient(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		input := gerrit.MoveInput{
+			DestinationBranch: branch,
+			Message:           request.GetString("message", ""),
+		}
+
+		change, err := client.MoveChange(ctx, changeID, input)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		return mcp.NewToolResultStructured(change, renderChange(change)), nil
+	}
+}
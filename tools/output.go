@@ -0,0 +1,231 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bajankristof/gerry/gerrit"
+	"github.com/bajankristof/gerry/git"
+)
+
+// changeList is the structured output of search_changes
+type changeList struct {
+	Changes []gerrit.Change `json:"changes"`
+	// MoreChanges is true when more changes match the query than were returned
+	MoreChanges bool `json:"moreChanges"`
+}
+
+// threadList is the structured output of the tools returning comment threads
+type threadList struct {
+	Files []fileThreads `json:"files"`
+}
+
+// fileList is the structured output of list_files
+type fileList struct {
+	Files []gerrit.FileInfo `json:"files"`
+}
+
+// commentList is the structured output of list_drafts
+type commentList struct {
+	Comments []gerrit.Comment `json:"comments"`
+}
+
+// reviewerList is the structured output of list_reviewers
+type reviewerList struct {
+	Reviewers []gerrit.Reviewer `json:"reviewers"`
+}
+
+// suggestionList is the structured output of suggest_reviewers
+type suggestionList struct {
+	Suggestions []gerrit.SuggestedReviewer `json:"suggestions"`
+}
+
+// stack is the structured output of get_stack
+type stack struct {
+	Commits []git.StackCommit `json:"commits"`
+}
+
+// renderChange renders a change as a few lines of text
+func renderChange(change gerrit.Change) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Change %d: %s\n", change.Number, change.Subject)
+	fmt.Fprintf(&sb, "Change-Id: %s\n", change.ChangeID)
+	fmt.Fprintf(&sb, "Project: %s, branch: %s", change.Project, change.Branch)
+	if change.Topic != "" {
+		fmt.Fprintf(&sb, ", topic: %s", change.Topic)
+	}
+	fmt.Fprintf(&sb, "\nStatus: %s", change.Status)
+	if change.Owner != nil {
+		fmt.Fprintf(&sb, ", owner: %s", change.Owner.DisplayName())
+	}
+	if revision, ok := change.Revisions[change.CurrentRevision]; ok {
+		fmt.Fprintf(&sb, "\nCurrent patch set: %d (%s)", revision.Number, change.CurrentRevision)
+	}
+	if change.Updated != "" {
+		fmt.Fprintf(&sb, "\nUpdated: %s", change.Updated)
+	}
+
+	return sb.String()
+}
+
+// renderChanges renders a list of changes, one line per change
+func renderChanges(changes []gerrit.Change) string {
+	lines := make([]string, 0, len(changes))
+	for _, change := range changes {
+		line := fmt.Sprintf("%d [%s] %s (%s): %s", change.Number, change.Status, change.Project, change.Branch, change.Subject)
+		if change.Owner != nil {
+			line += " - " + change.Owner.DisplayName()
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderThreads renders comment threads grouped by file, with every comment of each thread
+func renderThreads(files []fileThreads) string {
+	var sb strings.Builder
+
+	for i, file := range files {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "%s\n", file.Path)
+
+		for _, thread := range file.Threads {
+			state := "resolved"
+			if thread.Unresolved {
+				state = "unresolved"
+			}
+
+			location := "file"
+			if thread.Line > 0 {
+				location = fmt.Sprintf("line %d", thread.Line)
+			}
+			if thread.Side == "PARENT" {
+				location += " (parent)"
+			}
+
+			fmt.Fprintf(&sb, "  %s, patch set %d, %s:\n", location, thread.PatchSet, state)
+			for _, comment := range thread.Comments {
+				fmt.Fprintf(&sb, "    [%s] %s: %s\n", comment.ID, comment.Author.DisplayName(), indent(comment.Message, "      "))
+			}
+		}
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// renderFiles renders a list of files, one line per file
+func renderFiles(files []gerrit.FileInfo) string {
+	lines := make([]string, 0, len(files))
+	for _, file := range files {
+		line := fmt.Sprintf("%s %s", file.Status, file.Path)
+		if file.OldPath != "" {
+			line += " (from " + file.OldPath + ")"
+		}
+		if file.Binary {
+			line += " binary"
+		} else {
+			line += fmt.Sprintf(" +%d -%d", file.LinesInserted, file.LinesDeleted)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderComments renders a list of comments, one entry per comment
+func renderComments(comments []gerrit.Comment) string {
+	lines := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		location := comment.Path
+		if comment.Line > 0 {
+			location = fmt.Sprintf("%s:%d", comment.Path, comment.Line)
+		}
+
+		state := "resolved"
+		if comment.Unresolved {
+			state = "unresolved"
+		}
+
+		lines = append(lines, fmt.Sprintf("[%s] %s, patch set %d, %s: %s", comment.ID, location, comment.PatchSet, state, indent(comment.Message, "  ")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderReviewers renders a list of reviewers with their votes, one line per reviewer
+func renderReviewers(reviewers []gerrit.Reviewer) string {
+	lines := make([]string, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		line := reviewer.DisplayName()
+
+		labels := make([]string, 0, len(reviewer.Approvals))
+		for label := range reviewer.Approvals {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+
+		votes := make([]string, 0, len(labels))
+		for _, label := range labels {
+			votes = append(votes, fmt.Sprintf("%s %s", label, strings.TrimSpace(reviewer.Approvals[label])))
+		}
+		if len(votes) > 0 {
+			line += ": " + strings.Join(votes, ", ")
+		}
+
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderSuggestions renders a list of suggested reviewers, one line per account or group
+func renderSuggestions(suggestions []gerrit.SuggestedReviewer) string {
+	lines := make([]string, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		switch {
+		case suggestion.Account != nil:
+			lines = append(lines, suggestion.Account.DisplayName())
+		case suggestion.Group != nil:
+			line := fmt.Sprintf("group %s", suggestion.Group.Name)
+			if suggestion.Count > 0 {
+				line += fmt.Sprintf(" (%d members)", suggestion.Count)
+			}
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderStack renders the commits of a stack, one line per commit
+func renderStack(commits []git.StackCommit) string {
+	lines := make([]string, 0, len(commits))
+	for _, commit := range commits {
+		line := fmt.Sprintf("%s %s", commit.Commit, commit.Subject)
+		if commit.ChangeID != "" {
+			line += " (" + commit.ChangeID + ")"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderAddReviewer renders the reviewers and CCs added to a change
+func renderAddReviewer(result gerrit.AddReviewerResult) string {
+	lines := make([]string, 0, len(result.Reviewers)+len(result.CCs))
+	for _, reviewer := range result.Reviewers {
+		lines = append(lines, "Added reviewer "+reviewer.DisplayName())
+	}
+	for _, cc := range result.CCs {
+		lines = append(lines, "Added CC "+cc.DisplayName())
+	}
+	if len(lines) == 0 {
+		return fmt.Sprintf("%s is already on the change.", result.Input)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// indent indents every line of a multi-line message but the first
+func indent(message, prefix string) string {
+	return strings.ReplaceAll(strings.TrimSpace(message), "\n", "\n"+prefix)
+}
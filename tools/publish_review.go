@@ -11,40 +11,81 @@ import (
 
 // PublishReviewTool is the tool definition for publish_review
 var PublishReviewTool = mcp.NewTool("publish_review",
-	mcp.WithDescription("Submit and publish a review for a Gerrit change. This publishes all draft comments (making them visible to others) and optionally includes a review message."),
+	mcp.WithDescription("Submit and publish a review for a Gerrit change. This publishes all draft comments (making them visible to others) and optionally includes a review message and label votes (e.g., Code-Review +1 or Verified -1). Votes are validated against the labels you are permitted to vote on."),
+	mcp.WithReadOnlyHintAnnotation(false),
+	mcp.WithDestructiveHintAnnotation(false),
 	mcp.WithString("changeId",
 		mcp.Description("The Gerrit Change-Id (e.g., I1234567890abcdef...). Optional - if not provided, automatically uses the Change-Id from the current git commit."),
 	),
+	mcp.WithString("commit",
+		mcp.Description("The local git commit to take the Change-Id from when changeId is omitted, for working on changes deeper in a stack (e.g., HEAD~2 or a commit SHA; default: HEAD)"),
+	),
 	mcp.WithString("message",
 		mcp.Description("Optional review message to include with the published comments"),
 	),
+	mcp.WithObject("labels",
+		mcp.Description("Optional label votes to cast, mapping label names to scores (e.g., {\"Code-Review\": 1, \"Verified\": -1})"),
+		mcp.AdditionalProperties(map[string]any{"type": "integer"}),
+	),
 	mcp.WithString("directory",
 		mcp.Description("The directory containing the git repository (used to determine Gerrit host)"),
 	),
+	mcp.WithString("remote",
+		mcp.Description("The git remote pointing at Gerrit (default: auto-detected from the configured Gerrit host or a remote pushing to refs/for/*)"),
+	),
 )
 
 // HandlePublishReview handles the publish_review tool call
 func HandlePublishReview(cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		changeID, err := inferChangeID(request)
+		changeID, err := inferChangeID(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		client, err := newClient(cfg, request)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error: %v", err)), nil
+			return toolError(ctx, err), nil
 		}
 
-		directory := request.GetString("directory", "")
-		client, err := gerrit.NewClientFromGit(directory, cfg.GerritUsername, cfg.GerritPassword)
+		labels, err := getLabels(request)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error: %v", err)), nil
+			return toolError(ctx, err), nil
 		}
 
 		input := gerrit.PublishReviewInput{
 			Message: request.GetString("message", ""),
+			Labels:  labels,
 		}
 
-		if err := client.PublishReview(changeID, input); err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error: %v", err)), nil
+		if err := client.PublishReview(ctx, changeID, input); err != nil {
+			return toolError(ctx, err), nil
 		}
 
 		return mcp.NewToolResultText("Success."), nil
 	}
 }
+
+// getLabels extracts the label votes from the request
+func getLabels(request mcp.CallToolRequest) (map[string]int, error) {
+	raw, ok := request.GetArguments()["labels"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	values, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("labels must be an object mapping label names to scores")
+	}
+
+	labels := make(map[string]int, len(values))
+	for label, value := range values {
+		score, ok := value.(float64)
+		if !ok || score != float64(int(score)) {
+			return nil, fmt.Errorf("score for label %s must be an integer", label)
+		}
+		labels[label] = int(score)
+	}
+
+	return labels, nil
+}
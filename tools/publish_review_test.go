@@ -0,0 +1,38 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestGetLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    map[string]int
+		wantErr bool
+	}{
+		{name: "no labels", args: map[string]any{}},
+		{name: "scores", args: map[string]any{"labels": map[string]any{"Code-Review": 2.0, "Verified": -1.0}}, want: map[string]int{"Code-Review": 2, "Verified": -1}},
+		{name: "fractional score", args: map[string]any{"labels": map[string]any{"Code-Review": 1.5}}, wantErr: true},
+		{name: "string score", args: map[string]any{"labels": map[string]any{"Code-Review": "+2"}}, wantErr: true},
+		{name: "not an object", args: map[string]any{"labels": []any{"Code-Review"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var request mcp.CallToolRequest
+			request.Params.Arguments = tt.args
+
+			got, err := getLabels(request)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getLabels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/bajankristof/gerry/gerrit"
+	"github.com/bajankristof/gerry/git"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// PushChangeTool is the tool definition for push_change
+var PushChangeTool = mcp.NewTool("push_change",
+	mcp.WithDescription("Push a local commit (and the commits below it) to Gerrit for review, creating new changes or new patch sets of existing ones. Pushes to refs/for/<branch> with Gerrit push options such as topic, hashtags, reviewers and work-in-progress state. Returns the URLs, numbers and patch sets of the pushed changes."),
+	mcp.WithReadOnlyHintAnnotation(false),
+	mcp.WithDestructiveHintAnnotation(false),
+	mcp.WithOutputSchema[pushResult](),
+	mcp.WithString("branch",
+		mcp.Description("The target branch of the review (default: the branch the current branch tracks)"),
+	),
+	mcp.WithString("commit",
+		mcp.Description("The local commit to push (default: HEAD)"),
+	),
+	mcp.WithString("topic",
+		mcp.Description("The topic to set on the pushed changes"),
+	),
+	mcp.WithArray("hashtags",
+		mcp.Description("Hashtags to add to the pushed changes"),
+		mcp.WithStringItems(),
+	),
+	mcp.WithArray("reviewers",
+		mcp.Description("Reviewers to add (usernames or emails)"),
+		mcp.WithStringItems(),
+	),
+	mcp.WithArray("cc",
+		mcp.Description("Accounts to CC (usernames or emails)"),
+		mcp.WithStringItems(),
+	),
+	mcp.WithBoolean("wip",
+		mcp.Description("Mark the pushed changes as work in progress"),
+	),
+	mcp.WithBoolean("ready",
+		mcp.Description("Mark the pushed changes as ready for review"),
+	),
+	mcp.WithString("message",
+		mcp.Description("A message describing the new patch set"),
+	),
+	mcp.WithString("notify",
+		mcp.Description("Who to notify about the push (default: ALL)"),
+		mcp.Enum("NONE", "OWNER", "OWNER_REVIEWERS", "ALL"),
+	),
+	mcp.WithBoolean("publishComments",
+		mcp.Description("Publish your draft comments on the updated changes along with the push"),
+	),
+	mcp.WithString("directory",
+		mcp.Description("The directory containing the git repository"),
+	),
+	mcp.WithString("remote",
+		mcp.Description("The git remote pointing at Gerrit (default: auto-detected from the configured Gerrit host or a remote pushing to refs/for/*)"),
+	),
+)
+
+// pushResult represents the outcome of push_change
+type pushResult struct {
+	Changes  []git.PushedChange `json:"changes"`
+	ChangeID string             `json:"changeId,omitempty"`
+	Warnings []string           `json:"warnings,omitempty"`
+}
+
+// HandlePushChange handles the push_change tool call
+func HandlePushChange(cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		directory := request.GetString("directory", "")
+		if directory == "" {
+			directory = "."
+		}
+
+		if request.GetBool("wip", false) && request.GetBool("ready", false) {
+			return mcp.NewToolResultError("wip and ready cannot both be set"), nil
+		}
+
+		remote, err := git.GetRemote(directory, request.GetString("remote", cfg.Remote), cfg.KnownHosts())
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		branch := request.GetString("branch", "")
+		if branch == "" {
+			branch, err = git.GetUpstreamBranch(directory)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Error: %v (provide the target branch explicitly)", err)), nil
+			}
+		}
+
+		commit := request.GetString("commit", "HEAD")
+		opts := git.PushOptions{
+			Topic:           request.GetString("topic", ""),
+			Hashtags:        request.GetStringSlice("hashtags", nil),
+			Reviewers:       request.GetStringSlice("reviewers", nil),
+			CC:              request.GetStringSlice("cc", nil),
+			WIP:             request.GetBool("wip", false),
+			Ready:           request.GetBool("ready", false),
+			Message:         request.GetString("message", ""),
+			Notify:          request.GetString("notify", ""),
+			PublishComments: request.GetBool("publishComments", false),
+		}
+
+		if cfg.DryRun {
+			if err := git.CheckPush(ctx, directory, remote.Name, commit, branch); err != nil {
+				return toolError(ctx, err), nil
+			}
+
+			return mcp.NewToolResultText("Dry run - the following command was not run:\n\n" + commandLine(git.PushArgs(remote.Name, commit, branch, opts))), nil
+		}
+
+		changes, err := git.Push(ctx, directory, remote.Name, commit, branch, opts)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		if changes == nil {
+			changes = []git.PushedChange{}
+		}
+		result := pushResult{Changes: changes}
+
+		if changeID, err := git.GetChangeIDFromRevision(directory, commit); err == nil {
+			result.ChangeID = changeID
+		}
+
+		// Gerrit only prints the patch set on some versions; look up the others
+		var client *gerrit.Client
+		for i, change := range result.Changes {
+			if change.PatchSet > 0 {
+				continue
+			}
+			if client == nil {
+				if client, err = newClient(cfg, request); err != nil {
+					result.Warnings = append(result.Warnings, fmt.Sprintf("could not look up the patch sets of the pushed changes: %v", err))
+					break
+				}
+			}
+
+			info, err := client.GetChange(ctx, strconv.Itoa(change.Number))
+			if err != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("could not look up the patch set of change %d: %v", change.Number, err))
+				continue
+			}
+			result.Changes[i].PatchSet = info.Revisions[info.CurrentRevision].Number
+		}
+
+		return mcp.NewToolResultStructured(result, renderPush(result)), nil
+	}
+}
+
+// renderPush renders the changes created or updated by a push, one line per change
+func renderPush(result pushResult) string {
+	if len(result.Changes) == 0 {
+		return "Pushed, but Gerrit reported no changes."
+	}
+
+	lines := make([]string, 0, len(result.Changes)+1)
+	for _, change := range result.Changes {
+		line := fmt.Sprintf("%d %s %s", change.Number, change.URL, change.Subject)
+		if change.PatchSet > 0 {
+			line += fmt.Sprintf(" (patch set %d)", change.PatchSet)
+		}
+		if change.New {
+			line += " [NEW]"
+		}
+		lines = append(lines, line)
+	}
+	for _, warning := range result.Warnings {
+		lines = append(lines, "Warning: "+warning)
+	}
+	return strings.Join(lines, "\n")
+}
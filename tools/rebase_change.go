@@ -0,0 +1,62 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/bajankristof/gerry/gerrit"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// RebaseChangeTool is the tool definition for rebase_change
+var RebaseChangeTool = mcp.NewTool("rebase_change",
+	mcp.WithDescription("Rebase a Gerrit change onto the tip of its target branch, or onto another change or commit. Returns the updated change."),
+	mcp.WithReadOnlyHintAnnotation(false),
+	mcp.WithDestructiveHintAnnotation(false),
+	mcp.WithOutputSchema[gerrit.Change](),
+	mcp.WithString("changeId",
+		mcp.Description("The Gerrit Change-Id (e.g., I1234567890abcdef...). Optional - if not provided, automatically uses the Change-Id from the current git commit."),
+	),
+	mcp.WithString("commit",
+		mcp.Description("The local git commit to take the Change-Id from when changeId is omitted, for working on changes deeper in a stack (e.g., HEAD~2 or a commit SHA; default: HEAD)"),
+	),
+	mcp.WithString("base",
+		mcp.Description("The change or commit SHA to rebase onto (omit to rebase onto the tip of the target branch)"),
+	),
+	mcp.WithBoolean("allowConflicts",
+		mcp.Description("Whether to create the new patch set with conflict markers if the rebase has conflicts (default: false)"),
+	),
+	mcp.WithString("directory",
+		mcp.Description("The directory containing the git repository (used to determine Gerrit host)"),
+	),
+	mcp.WithString("remote",
+		mcp.Description("The git remote pointing at Gerrit (default: auto-detected from the configured Gerrit host or a remote pushing to refs/for/*)"),
+	),
+)
+
+// HandleRebaseChange handles the rebase_change tool call
+func HandleRebaseChange(cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		changeID, err := inferChangeID(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		client, err := newClient(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		input := gerrit.RebaseInput{
+			Base:           request.GetString("base", ""),
+			AllowConflicts: request.GetBool("allowConflicts", false),
+		}
+
+		change, err := client.RebaseChange(ctx, changeID, input)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		return mcp.NewToolResultStructured(change, renderChange(change)), nil
+	}
+}
@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// RemoveReviewerTool is the tool definition for remove_reviewer
+var RemoveReviewerTool = mcp.NewTool("remove_reviewer",
+	mcp.WithDescription("Remove a reviewer or CC from a Gerrit change."),
+	mcp.WithReadOnlyHintAnnotation(false),
+	mcp.WithDestructiveHintAnnotation(true),
+	mcp.WithString("changeId",
+		mcp.Description("The Gerrit Change-Id (e.g., I1234567890abcdef...). Optional - if not provided, automatically uses the Change-Id from the current git commit."),
+	),
+	mcp.WithString("commit",
+		mcp.Description("The local git commit to take the Change-Id from when changeId is omitted, for working on changes deeper in a stack (e.g., HEAD~2 or a commit SHA; default: HEAD)"),
+	),
+	mcp.WithString("reviewer",
+		mcp.Required(),
+		mcp.Description("The account to remove (username, email or account ID)"),
+	),
+	mcp.WithString("directory",
+		mcp.Description("The directory containing the git repository (used to determine Gerrit host)"),
+	),
+	mcp.WithString("remote",
+		mcp.Description("The git remote pointing at Gerrit (default: auto-detected from the configured Gerrit host or a remote pushing to refs/for/*)"),
+	),
+)
+
+// HandleRemoveReviewer handles the remove_reviewer tool call
+func HandleRemoveReviewer(cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		changeID, err := inferChangeID(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		reviewer, err := request.RequireString("reviewer")
+		if err != nil {
+			return mcp.NewToolResultError("reviewer is required"), nil
+		}
+
+		client, err := newClient(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		if err := client.RemoveReviewer(ctx, changeID, reviewer); err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		return mcp.NewToolResultText("Success."), nil
+	}
+}
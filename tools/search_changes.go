@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/bajankristof/gerry/gerrit"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// SearchChangesTool is the tool definition for search_changes
+var SearchChangesTool = mcp.NewTool("search_changes",
+	mcp.WithDescription("Search Gerrit changes using Gerrit search operators, e.g. 'status:open owner:self', 'attention:self', 'project:foo file:src/main.go' or 'reviewer:self -owner:self status:open'. Returns matching changes with their number, Change-Id, project, branch, subject, status and owner."),
+	mcp.WithReadOnlyHintAnnotation(true),
+	mcp.WithOutputSchema[changeList](),
+	mcp.WithString("query",
+		mcp.Required(),
+		mcp.Description("The Gerrit search query"),
+	),
+	mcp.WithNumber("limit",
+		mcp.Description("The maximum number of changes to return (default: 25)"),
+	),
+	mcp.WithNumber("start",
+		mcp.Description("The number of changes to skip, used to fetch the next page of results (default: 0)"),
+	),
+	mcp.WithArray("options",
+		mcp.Description("Additional fields to include in the results (e.g., LABELS, CURRENT_REVISION, DETAILED_ACCOUNTS)"),
+		mcp.WithStringItems(),
+	),
+	mcp.WithString("directory",
+		mcp.Description("The directory containing the git repository (used to determine Gerrit host)"),
+	),
+	mcp.WithString("remote",
+		mcp.Description("The git remote pointing at Gerrit (default: auto-detected from the configured Gerrit host or a remote pushing to refs/for/*)"),
+	),
+)
+
+// HandleSearchChanges handles the search_changes tool call
+func HandleSearchChanges(cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		query, err := request.RequireString("query")
+		if err != nil {
+			return mcp.NewToolResultError("query is required"), nil
+		}
+
+		client, err := newClient(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		opts := gerrit.QueryOptions{
+			Limit:   request.GetInt("limit", 25),
+			Start:   request.GetInt("start", 0),
+			Options: request.GetStringSlice("options", nil),
+		}
+
+		changes, err := client.QueryChanges(ctx, query, opts)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		if len(changes) == 0 {
+			return mcp.NewToolResultStructured(changeList{Changes: []gerrit.Change{}}, "No changes found."), nil
+		}
+
+		result := changeList{Changes: changes, MoreChanges: changes[len(changes)-1].MoreChanges}
+
+		text := renderChanges(changes)
+		if result.MoreChanges {
+			text += fmt.Sprintf("\n\nMore changes are available. Use start=%d to fetch the next page.", opts.Start+len(changes))
+		}
+
+		return mcp.NewToolResultStructured(result, text), nil
+	}
+}
@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/bajankristof/gerry/gerrit"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// SuggestReviewersTool is the tool definition for suggest_reviewers
+var SuggestReviewersTool = mcp.NewTool("suggest_reviewers",
+	mcp.WithDescription("Suggest reviewers for a Gerrit change. Returns accounts and groups matching the query, ranked by Gerrit (which takes recent reviewers and owners of the touched files into account)."),
+	mcp.WithReadOnlyHintAnnotation(true),
+	mcp.WithOutputSchema[suggestionList](),
+	mcp.WithString("changeId",
+		mcp.Description("The Gerrit Change-Id (e.g., I1234567890abcdef...). Optional - if not provided, automatically uses the Change-Id from the current git commit."),
+	),
+	mcp.WithString("commit",
+		mcp.Description("The local git commit to take the Change-Id from when changeId is omitted, for working on changes deeper in a stack (e.g., HEAD~2 or a commit SHA; default: HEAD)"),
+	),
+	mcp.WithString("query",
+		mcp.Description("A name, username or email prefix to match (omit to get recommendations)"),
+	),
+	mcp.WithNumber("limit",
+		mcp.Description("The maximum number of suggestions to return (default: 10)"),
+	),
+	mcp.WithString("directory",
+		mcp.Description("The directory containing the git repository (used to determine Gerrit host)"),
+	),
+	mcp.WithString("remote",
+		mcp.Description("The git remote pointing at Gerrit (default: auto-detected from the configured Gerrit host or a remote pushing to refs/for/*)"),
+	),
+)
+
+// HandleSuggestReviewers handles the suggest_reviewers tool call
+func HandleSuggestReviewers(cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		changeID, err := inferChangeID(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		client, err := newClient(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		suggestions, err := client.SuggestReviewers(ctx, changeID, request.GetString("query", ""), request.GetInt("limit", 10))
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		if len(suggestions) == 0 {
+			return mcp.NewToolResultStructured(suggestionList{Suggestions: []gerrit.SuggestedReviewer{}}, "No suggested reviewers found."), nil
+		}
+
+		return mcp.NewToolResultStructured(suggestionList{Suggestions: suggestions}, renderSuggestions(suggestions)), nil
+	}
+}
@@ -1,42 +1,135 @@
 package tools
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/bajankristof/gerry/config"
-	"github.com/bajankristof/gerry/git"
+	"github.com/bajankristof/gerry/gerrit"
+	"github.com/bajankristof/gerry/session"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
-// Inject registers all Gerrit MCP tools with the server
+// Inject registers all Gerrit MCP tools with the server. In read-only mode only the tools
+// that modify neither Gerrit nor the local repository are registered. A shared server taking
+// session credentials has no repository of its own, so the tools that run git are left out.
 func Inject(s *server.MCPServer, cfg *config.Config) {
-	s.AddTool(GetChangeIDTool, HandleGetChangeID)
+	if !cfg.SessionCredentials {
+		s.AddTool(GetChangeIDTool, HandleGetChangeID)
+		s.AddTool(GetStackTool, HandleGetStack)
+		s.AddTool(LocateCommentTool, HandleLocateComment(cfg))
+	}
 	s.AddTool(GetChangeTool, HandleGetChange(cfg))
+	s.AddTool(SearchChangesTool, HandleSearchChanges(cfg))
 	s.AddTool(GetCommentsTool, HandleGetComments(cfg))
 	s.AddTool(GetUnresolvedCommentsTool, HandleGetUnresolvedComments(cfg))
-	s.AddTool(DraftCommentTool, HandleDraftComment(cfg))
-	s.AddTool(PublishReviewTool, HandlePublishReview(cfg))
+	s.AddTool(ListFilesTool, HandleListFiles(cfg))
+	s.AddTool(GetDiffTool, HandleGetDiff(cfg))
+	s.AddTool(ListReviewersTool, HandleListReviewers(cfg))
+	s.AddTool(SuggestReviewersTool, HandleSuggestReviewers(cfg))
+	s.AddTool(ListDraftsTool, HandleListDrafts(cfg))
+
+	if cfg.ReadOnly {
+		return
+	}
+
+	// A dry run answers with the request or commands it did not send, which the output schema of
+	// a mutating tool does not describe, so in dry-run mode these tools declare none
+	addMutatingTool := func(tool mcp.Tool, handler server.ToolHandlerFunc) {
+		if cfg.DryRun {
+			tool.OutputSchema = mcp.ToolOutputSchema{}
+		}
+		s.AddTool(tool, handler)
+	}
+
+	addMutatingTool(AddReviewerTool, HandleAddReviewer(cfg))
+	addMutatingTool(RemoveReviewerTool, HandleRemoveReviewer(cfg))
+	addMutatingTool(DraftCommentTool, HandleDraftComment(cfg))
+	addMutatingTool(UpdateDraftTool, HandleUpdateDraft(cfg))
+	addMutatingTool(DeleteDraftTool, HandleDeleteDraft(cfg))
+	addMutatingTool(PublishReviewTool, HandlePublishReview(cfg))
+	addMutatingTool(SubmitChangeTool, HandleSubmitChange(cfg))
+	addMutatingTool(AbandonChangeTool, HandleAbandonChange(cfg))
+	addMutatingTool(RestoreChangeTool, HandleRestoreChange(cfg))
+	addMutatingTool(RebaseChangeTool, HandleRebaseChange(cfg))
+	addMutatingTool(MoveChangeTool, HandleMoveChange(cfg))
+
+	if !cfg.SessionCredentials {
+		addMutatingTool(PushChangeTool, HandlePushChange(cfg))
+		addMutatingTool(CheckoutChangeTool, HandleCheckoutChange(cfg))
+		addMutatingTool(InstallCommitMsgHookTool, HandleInstallCommitMsgHook(cfg))
+	}
 }
 
-// inferChangeID extracts changeId from the request or auto-detects it from git
-func inferChangeID(request mcp.CallToolRequest) (string, error) {
-	changeID := request.GetString("changeId", "")
+// newClient creates a Gerrit client for the repository in the request's directory,
+// using the configuration of the Gerrit host its remote points at
+func newClient(cfg *config.Config, request mcp.CallToolRequest) (*gerrit.Client, error) {
+	return session.NewClient(cfg, request.Header, request.GetString("directory", ""), request.GetString("remote", ""))
+}
 
-	if changeID != "" {
-		return changeID, nil
+// commandLine formats git arguments as a command line, quoting arguments that contain spaces or quotes
+func commandLine(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = arg
+		if strings.ContainsAny(arg, " \t\"'") {
+			quoted[i] = strconv.Quote(arg)
+		}
 	}
+	return "git " + strings.Join(quoted, " ")
+}
 
-	// Auto-detect from git
-	directory := request.GetString("directory", "")
-	changeID, err := git.GetChangeIDFromCommit(directory)
-	if err != nil {
-		return "", fmt.Errorf("could not auto-detect changeId from git: %w", err)
+// toolError converts an error into a tool result, reporting cancellations and timeouts cleanly
+func toolError(ctx context.Context, err error) *mcp.CallToolResult {
+	var dryRun *gerrit.DryRunError
+	if errors.As(err, &dryRun) {
+		return mcp.NewToolResultText("Dry run - the following request was not sent:\n\n" + dryRun.Request())
 	}
 
-	if changeID == "" {
-		return "", fmt.Errorf("no Change-Id found in current commit")
+	if errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled) {
+		return mcp.NewToolResultError("Request cancelled.")
 	}
 
-	return changeID, nil
+	if errors.Is(err, context.DeadlineExceeded) {
+		return mcp.NewToolResultError("Error: request to Gerrit timed out")
+	}
+
+	switch {
+	case gerrit.IsAuth(err):
+		return mcp.NewToolResultError(fmt.Sprintf("Error: %v (check your Gerrit credentials and permissions)", err))
+	case gerrit.IsNotFound(err):
+		return mcp.NewToolResultError(fmt.Sprintf("Error: %v (the change or resource does not exist or is not visible to you)", err))
+	case gerrit.IsConflict(err):
+		return mcp.NewToolResultError(fmt.Sprintf("Error: %v (the change is not in a state that allows this operation)", err))
+	}
+
+	return mcp.NewToolResultError(fmt.Sprintf("Error: %v", err))
+}
+
+// fileThreads represents the comment threads on a single file
+type fileThreads struct {
+	Path    string          `json:"path"`
+	Threads []gerrit.Thread `json:"threads"`
+}
+
+// groupThreadsByFile groups threads sorted by file into one entry per file
+func groupThreadsByFile(threads []gerrit.Thread) []fileThreads {
+	result := []fileThreads{}
+	for _, thread := range threads {
+		if len(result) == 0 || result[len(result)-1].Path != thread.Path {
+			result = append(result, fileThreads{Path: thread.Path})
+		}
+		last := &result[len(result)-1]
+		last.Threads = append(last.Threads, thread)
+	}
+	return result
+}
+
+// inferChangeID extracts changeId from the request or auto-detects it from git
+func inferChangeID(cfg *config.Config, request mcp.CallToolRequest) (string, error) {
+	return session.ChangeID(cfg, request.GetString("changeId", ""), request.GetString("directory", ""), request.GetString("commit", ""))
 }
@@ -0,0 +1,137 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func TestInjectRegistration(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        config.Config
+		registered []string
+		missing    []string
+	}{
+		{
+			name:       "default",
+			registered: []string{"get_change", "get_change_id", "list_drafts", "abandon_change", "draft_comment", "publish_review", "push_change", "checkout_change", "install_commit_msg_hook"},
+		},
+		{
+			name:       "read-only",
+			cfg:        config.Config{ReadOnly: true},
+			registered: []string{"get_change", "get_change_id", "get_comments", "locate_comment", "list_drafts", "get_diff"},
+			missing:    []string{"abandon_change", "draft_comment", "update_draft", "delete_draft", "publish_review", "add_reviewer", "push_change", "checkout_change", "install_commit_msg_hook"},
+		},
+		{
+			name:       "shared server",
+			cfg:        config.Config{SessionCredentials: true},
+			registered: []string{"get_change", "get_comments", "list_drafts", "abandon_change", "draft_comment", "publish_review"},
+			missing:    []string{"get_change_id", "get_stack", "locate_comment", "push_change", "checkout_change", "install_commit_msg_hook"},
+		},
+		{
+			name:       "dry-run",
+			cfg:        config.Config{DryRun: true},
+			registered: []string{"get_change", "abandon_change", "publish_review", "push_change"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := server.NewMCPServer("test", "0.0.0")
+			Inject(s, &tt.cfg)
+
+			for _, name := range tt.registered {
+				if s.GetTool(name) == nil {
+					t.Errorf("%s is not registered", name)
+				}
+			}
+			for _, name := range tt.missing {
+				if s.GetTool(name) != nil {
+					t.Errorf("%s is registered", name)
+				}
+			}
+		})
+	}
+}
+
+func TestDryRunTool(t *testing.T) {
+	var mu sync.Mutex
+	var methods []string
+	gerrit := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		methods = append(methods, r.Method)
+		mu.Unlock()
+		w.Write([]byte(")]}'\n{}"))
+	}))
+	defer gerrit.Close()
+
+	cfg := &config.Config{
+		GerritHost:         "review.example.com",
+		Hosts:              map[string]config.HostConfig{"review.example.com": {BaseURL: gerrit.URL}},
+		DryRun:             true,
+		SessionCredentials: true,
+	}
+
+	var request mcp.CallToolRequest
+	request.Params.Arguments = map[string]any{"changeId": "123", "message": "Superseded"}
+	request.Header = http.Header{}
+	request.Header.Set("Authorization", "Basic amFuZTpzZWNyZXQ=")
+
+	result, err := HandleAbandonChange(cfg)(context.Background(), request)
+	if err != nil {
+		t.Fatalf("abandon_change error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("abandon_change result is an error: %+v", result.Content)
+	}
+
+	text := result.Content[0].(mcp.TextContent).Text
+	if want := "POST " + gerrit.URL + "/a/changes/123/abandon"; !strings.HasPrefix(text, "Dry run") || !strings.Contains(text, want) || !strings.Contains(text, "Superseded") {
+		t.Errorf("abandon_change result = %q, want the dry run of %s", text, want)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(methods) != 0 {
+		t.Errorf("Gerrit received %v, want no requests", methods)
+	}
+}
+
+func TestInjectOutputSchemas(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    config.Config
+		tool   string
+		schema bool
+	}{
+		{name: "read tool", tool: "get_change", schema: true},
+		{name: "read tool in dry-run mode", cfg: config.Config{DryRun: true}, tool: "get_change", schema: true},
+		{name: "mutating tool", tool: "abandon_change", schema: true},
+		{name: "mutating tool in dry-run mode", cfg: config.Config{DryRun: true}, tool: "abandon_change"},
+		{name: "push in dry-run mode", cfg: config.Config{DryRun: true}, tool: "push_change"},
+		{name: "update draft in dry-run mode", cfg: config.Config{DryRun: true}, tool: "update_draft"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := server.NewMCPServer("test", "0.0.0")
+			Inject(s, &tt.cfg)
+
+			tool := s.GetTool(tt.tool)
+			if tool == nil {
+				t.Fatalf("%s is not registered", tt.tool)
+			}
+			if got := tool.Tool.OutputSchema.Type != ""; got != tt.schema {
+				t.Errorf("%s declares an output schema = %v, want %v", tt.tool, got, tt.schema)
+			}
+		})
+	}
+}
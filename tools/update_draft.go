@@ -0,0 +1,78 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/bajankristof/gerry/config"
+	"github.com/bajankristof/gerry/gerrit"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// UpdateDraftTool is the tool definition for update_draft
+var UpdateDraftTool = mcp.NewTool("update_draft",
+	mcp.WithDescription("Update the message or resolution of one of your draft comments on a Gerrit change. Use list_drafts to find draft IDs."),
+	mcp.WithReadOnlyHintAnnotation(false),
+	mcp.WithDestructiveHintAnnotation(false),
+	mcp.WithOutputSchema[gerrit.Comment](),
+	mcp.WithString("changeId",
+		mcp.Description("The Gerrit Change-Id (e.g., I1234567890abcdef...). Optional - if not provided, automatically uses the Change-Id from the current git commit."),
+	),
+	mcp.WithString("commit",
+		mcp.Description("The local git commit to take the Change-Id from when changeId is omitted, for working on changes deeper in a stack (e.g., HEAD~2 or a commit SHA; default: HEAD)"),
+	),
+	mcp.WithString("draftId",
+		mcp.Required(),
+		mcp.Description("The ID of the draft to update"),
+	),
+	mcp.WithString("message",
+		mcp.Description("The new message of the draft (omit to keep the current message)"),
+	),
+	mcp.WithBoolean("unresolved",
+		mcp.Description("Whether the draft should be marked as unresolved (omit to keep the current state)"),
+	),
+	mcp.WithString("directory",
+		mcp.Description("The directory containing the git repository (used to determine Gerrit host)"),
+	),
+	mcp.WithString("remote",
+		mcp.Description("The git remote pointing at Gerrit (default: auto-detected from the configured Gerrit host or a remote pushing to refs/for/*)"),
+	),
+)
+
+// HandleUpdateDraft handles the update_draft tool call
+func HandleUpdateDraft(cfg *config.Config) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		changeID, err := inferChangeID(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		draftID, err := request.RequireString("draftId")
+		if err != nil {
+			return mcp.NewToolResultError("draftId is required"), nil
+		}
+
+		var input gerrit.UpdateDraftInput
+		if message, err := request.RequireString("message"); err == nil {
+			input.Message = &message
+		}
+		if unresolved, err := request.RequireBool("unresolved"); err == nil {
+			input.Unresolved = &unresolved
+		}
+
+		if input.Message == nil && input.Unresolved == nil {
+			return mcp.NewToolResultError("message or unresolved is required"), nil
+		}
+
+		client, err := newClient(cfg, request)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		draft, err := client.UpdateDraft(ctx, changeID, draftID, input)
+		if err != nil {
+			return toolError(ctx, err), nil
+		}
+
+		return mcp.NewToolResultStructured(draft, renderComments([]gerrit.Comment{draft})), nil
+	}
+}